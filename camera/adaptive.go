@@ -0,0 +1,84 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/canvas"
+	"github.com/kieron-pivotal/rays/color"
+	"github.com/kieron-pivotal/rays/internal/splitmix"
+	"github.com/kieron-pivotal/rays/world"
+)
+
+// refineTile re-samples the pixels of first that contrast with a neighbour
+// by more than AdaptiveThreshold, writing the results into image.
+func (c Camera) refineTile(run *renderRun, w *world.World, first, image *canvas.Canvas, t tile) bool {
+	refined, extra := 0, 0
+	defer func() { run.addRefined(refined, extra) }()
+
+	for py := t.y0; py < t.y1; py++ {
+		if run.ctx.Err() != nil {
+			return false
+		}
+		for px := t.x0; px < t.x1; px++ {
+			if !c.needsRefining(first, px, py) {
+				continue
+			}
+			refined++
+			rnd := newPixelRand(c.Seed, px, py)
+			image.SetPixel(px, py, c.subdivide(w, rnd, px, py, 0, 0, 1, c.AdaptiveDepth, &extra))
+		}
+	}
+	return true
+}
+
+func (c Camera) needsRefining(first *canvas.Canvas, px, py int) bool {
+	centre := first.Pixel(px, py)
+	for _, d := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+		x, y := px+d[0], py+d[1]
+		if x < 0 || y < 0 || x >= c.HSize || y >= c.VSize {
+			continue
+		}
+		if contrast(centre, first.Pixel(x, y)) > c.AdaptiveThreshold {
+			return true
+		}
+	}
+	return false
+}
+
+// subdivide samples the corners of the square of the given size at (x, y)
+// within the pixel, splitting it into four while the corners disagree and
+// depth remains.
+func (c Camera) subdivide(w *world.World, rnd *splitmix.Rand, px, py int, x, y, size float64, depth int, rays *int) color.Color {
+	corners := [4]color.Color{}
+	for i, o := range [][2]float64{{x, y}, {x + size, y}, {x, y + size}, {x + size, y + size}} {
+		corners[i] = w.ColorAt(c.sampleRay(rnd, px, py, o[0], o[1]))
+		*rays++
+	}
+
+	spread := 0.0
+	for i := 1; i < len(corners); i++ {
+		spread = math.Max(spread, contrast(corners[0], corners[i]))
+	}
+
+	if depth <= 0 || spread <= c.AdaptiveThreshold {
+		sum := color.New(0, 0, 0)
+		for _, corner := range corners {
+			sum = sum.Add(corner)
+		}
+		return sum.Multiply(0.25)
+	}
+
+	half := size / 2
+	return c.subdivide(w, rnd, px, py, x, y, half, depth-1, rays).
+		Add(c.subdivide(w, rnd, px, py, x+half, y, half, depth-1, rays)).
+		Add(c.subdivide(w, rnd, px, py, x, y+half, half, depth-1, rays)).
+		Add(c.subdivide(w, rnd, px, py, x+half, y+half, half, depth-1, rays)).
+		Multiply(0.25)
+}
+
+func contrast(a, b color.Color) float64 {
+	return math.Max(
+		math.Abs(a.Red()-b.Red()),
+		math.Max(math.Abs(a.Green()-b.Green()), math.Abs(a.Blue()-b.Blue())),
+	)
+}
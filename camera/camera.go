@@ -3,36 +3,82 @@ package camera
 import (
 	"math"
 
-	"github.com/kieron-pivotal/rays/canvas"
 	"github.com/kieron-pivotal/rays/matrix"
 	"github.com/kieron-pivotal/rays/ray"
 	"github.com/kieron-pivotal/rays/tuple"
-	"github.com/kieron-pivotal/rays/world"
 )
 
 type Camera struct {
-	HSize            int
-	VSize            int
-	FieldOfView      float64
-	transform        matrix.Matrix
-	inverseTransform matrix.Matrix
-	HalfWidth        float64
-	HalfHeight       float64
-	PixelSize        float64
+	HSize             int
+	VSize             int
+	FieldOfView       float64
+	Projection        Projection
+	ViewSize          float64
+	transform         matrix.Matrix
+	inverseTransform  matrix.Matrix
+	HalfWidth         float64
+	HalfHeight        float64
+	PixelSize         float64
+	Workers           int
+	TileSize          int
+	Sampling          Sampling
+	Samples           int
+	Seed              int64
+	AdaptiveThreshold float64
+	AdaptiveDepth     int
+	Aperture          float64
+	FocalDistance     float64
 }
 
 func New(hsize, vsize int, fieldOfView float64) Camera {
 	c := Camera{
-		HSize:            hsize,
-		VSize:            vsize,
-		FieldOfView:      fieldOfView,
-		transform:        matrix.Identity(4, 4),
-		inverseTransform: matrix.Identity(4, 4),
+		HSize:             hsize,
+		VSize:             vsize,
+		FieldOfView:       fieldOfView,
+		transform:         matrix.Identity(4, 4),
+		inverseTransform:  matrix.Identity(4, 4),
+		Workers:           1,
+		TileSize:          16,
+		Sampling:          SampleCentre,
+		Samples:           1,
+		AdaptiveThreshold: 0.1,
+		AdaptiveDepth:     2,
+		FocalDistance:     1,
 	}
 	c.calcSizes()
 	return c
 }
 
+// NewOrthographic returns a camera with parallel rays whose longer side
+// spans viewSize world units.
+func NewOrthographic(hsize, vsize int, viewSize float64) Camera {
+	c := New(hsize, vsize, 0)
+	c.Projection = ProjectionOrthographic
+	c.ViewSize = viewSize
+	c.calcSizes()
+	return c
+}
+
+// NewPanoramic returns a camera that sees the full sphere around it as an
+// equirectangular image: longitude runs across and latitude down, with the
+// view direction in the centre. A 2:1 image keeps pixels square.
+func NewPanoramic(hsize, vsize int) Camera {
+	c := New(hsize, vsize, 2*math.Pi)
+	c.Projection = ProjectionPanoramic
+	c.calcSizes()
+	return c
+}
+
+// NewFisheye returns an equidistant fisheye camera, where distance from the
+// image centre is proportional to the angle off the view direction and the
+// longer side spans fieldOfView, which may exceed π.
+func NewFisheye(hsize, vsize int, fieldOfView float64) Camera {
+	c := New(hsize, vsize, fieldOfView)
+	c.Projection = ProjectionFisheye
+	c.calcSizes()
+	return c
+}
+
 func (c *Camera) SetTransform(m matrix.Matrix) {
 	c.transform = m
 	c.inverseTransform = m.Inverse()
@@ -43,7 +89,20 @@ func (c *Camera) GetTransform() matrix.Matrix {
 }
 
 func (c *Camera) calcSizes() {
+	if c.Projection == ProjectionPanoramic {
+		c.HalfWidth = math.Pi
+		c.HalfHeight = math.Pi / 2
+		c.PixelSize = 2 * math.Pi / float64(c.HSize)
+		return
+	}
+
 	halfView := math.Tan(c.FieldOfView / 2)
+	switch c.Projection {
+	case ProjectionOrthographic:
+		halfView = c.ViewSize / 2
+	case ProjectionFisheye:
+		halfView = c.FieldOfView / 2
+	}
 	aspect := float64(c.HSize) / float64(c.VSize)
 
 	if aspect >= 1 {
@@ -57,25 +116,52 @@ func (c *Camera) calcSizes() {
 }
 
 func (c Camera) RayForPixel(px, py int) ray.Ray {
-	xoffset := (float64(px) + 0.5) * c.PixelSize
-	yoffset := (float64(py) + 0.5) * c.PixelSize
-	worldX := c.HalfWidth - xoffset
-	worldY := c.HalfHeight - yoffset
-	pixel := c.inverseTransform.TupleMultiply(tuple.Point(worldX, worldY, -1))
-	origin := c.inverseTransform.TupleMultiply(tuple.Point(0, 0, 0))
-	direction := pixel.Subtract(origin).Normalize()
-	return ray.New(origin, direction)
+	return c.RayForPixelOffset(px, py, 0.5, 0.5)
 }
 
-func (c Camera) Render(w *world.World) *canvas.Canvas {
-	image := canvas.New(c.HSize, c.VSize)
+// RayForPixelOffset aims at the point (dx, dy) within the pixel, where
+// both offsets run from 0 to 1 and 0.5, 0.5 is the pixel centre.
+func (c Camera) RayForPixelOffset(px, py int, dx, dy float64) ray.Ray {
+	return c.RayForPixelLens(px, py, dx, dy, 0, 0)
+}
+
+// RayForPixelLens is RayForPixelOffset for a thin lens of diameter
+// Aperture: the ray leaves the lens at (lu, lv), measured in radii from
+// its centre, and passes through the point on the focal plane that the
+// pinhole ray would hit. Only objects FocalDistance away stay sharp; a
+// FocalDistance of zero or less is taken as 1.
+func (c Camera) RayForPixelLens(px, py int, dx, dy, lu, lv float64) ray.Ray {
+	xoffset := (float64(px) + dx) * c.PixelSize
+	yoffset := (float64(py) + dy) * c.PixelSize
+	worldX := c.HalfWidth - xoffset
+	worldY := c.HalfHeight - yoffset
 
-	for py := 0; py < c.VSize; py++ {
-		for px := 0; px < c.HSize; px++ {
-			ray := c.RayForPixel(px, py)
-			color := w.ColorAt(ray)
-			image.SetPixel(px, py, color)
+	// without a lens the focal distance doesn't matter, so aim as a pinhole
+	f := c.FocalDistance
+	radius := math.Max(c.Aperture, 0) / 2
+	if radius == 0 || f <= 0 {
+		f = 1
+	}
+	var origin, focus tuple.Tuple
+	switch c.Projection {
+	case ProjectionOrthographic:
+		origin = tuple.Point(worldX+lu*radius, worldY+lv*radius, 0)
+		focus = tuple.Point(worldX, worldY, -f)
+	case ProjectionPanoramic, ProjectionFisheye:
+		var dir tuple.Tuple
+		if c.Projection == ProjectionPanoramic {
+			dir = c.panoramicDirection(float64(px)+dx, float64(py)+dy)
+		} else {
+			dir = fisheyeDirection(worldX, worldY)
 		}
+		origin = tuple.Point(0, 0, 0).Add(lensOffset(dir, lu*radius, lv*radius))
+		focus = tuple.Point(0, 0, 0).Add(dir.Multiply(f))
+	default:
+		origin = tuple.Point(lu*radius, lv*radius, 0)
+		focus = tuple.Point(worldX*f, worldY*f, -f)
 	}
-	return image
+	origin = c.inverseTransform.TupleMultiply(origin)
+	focus = c.inverseTransform.TupleMultiply(focus)
+	direction := focus.Subtract(origin).Normalize()
+	return ray.New(origin, direction)
 }
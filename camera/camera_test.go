@@ -1,14 +1,19 @@
 package camera_test
 
 import (
+	"context"
 	"math"
+	"sync"
 
 	"github.com/kieron-pivotal/rays/camera"
+	"github.com/kieron-pivotal/rays/canvas"
 	"github.com/kieron-pivotal/rays/color"
 	"github.com/kieron-pivotal/rays/matrix"
+	"github.com/kieron-pivotal/rays/ray"
 	"github.com/kieron-pivotal/rays/tuple"
 	"github.com/kieron-pivotal/rays/world"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
@@ -69,5 +74,504 @@ var _ = Describe("Camera", func() {
 			image := c.Render(w)
 			Expect(image.Pixel(5, 5)).To(color.Equal(color.New(0.38066, 0.47583, 0.2855)))
 		})
+
+		It("renders serially by default", func() {
+			c := camera.New(11, 11, math.Pi/2)
+			Expect(c.Workers).To(Equal(1))
+			Expect(c.TileSize).To(BeNumerically(">", 0))
+		})
+
+		It("renders the same image in parallel tiles", func() {
+			w := world.Default()
+			c := camera.New(43, 29, math.Pi/2)
+			c.SetTransform(matrix.ViewTransformation(
+				tuple.Point(0, 0, -5),
+				tuple.Point(0, 0, 0),
+				tuple.Vector(0, 1, 0),
+			))
+			serial := c.Render(w)
+
+			c.Workers = 4
+			c.TileSize = 7
+			parallel := c.Render(w)
+
+			for y := 0; y < c.VSize; y++ {
+				for x := 0; x < c.HSize; x++ {
+					Expect(parallel.Pixel(x, y)).To(Equal(serial.Pixel(x, y)))
+				}
+			}
+		})
+
+		It("leaves the world as it found it", func() {
+			w := world.Default()
+			c := camera.New(11, 11, math.Pi/2)
+			var wg sync.WaitGroup
+			for i := 0; i < 2; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					c.Render(w)
+				}()
+			}
+			wg.Wait()
+
+			w.Objects[0].SetTransform(matrix.Translation(10, 0, 0))
+			r := ray.New(tuple.Point(10, 0, -5), tuple.Vector(0, 0, 1))
+			Expect(w.Intersections(r).Count()).To(Equal(2))
+		})
+	})
+
+	Context("rendering with a context", func() {
+		var (
+			w *world.World
+			c camera.Camera
+		)
+
+		BeforeEach(func() {
+			w = world.Default()
+			c = camera.New(40, 30, math.Pi/2)
+			c.SetTransform(matrix.ViewTransformation(
+				tuple.Point(0, 0, -5),
+				tuple.Point(0, 0, 0),
+				tuple.Vector(0, 1, 0),
+			))
+			c.TileSize = 10
+		})
+
+		It("reports progress after every tile", func() {
+			reports := []camera.Progress{}
+			image, _, err := c.RenderContext(context.Background(), w, func(p camera.Progress) {
+				reports = append(reports, p)
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reports).To(HaveLen(12))
+			for i, p := range reports {
+				Expect(p.TilesDone).To(Equal(i + 1))
+				Expect(p.TilesTotal).To(Equal(12))
+			}
+			last := reports[len(reports)-1]
+			Expect(last.ETA).To(BeZero())
+			Expect(last.Elapsed).To(BeNumerically(">", 0))
+			Expect(image.Pixel(20, 15)).To(Equal(c.Render(w).Pixel(20, 15)))
+		})
+
+		It("stops when the context is cancelled", func() {
+			c.Workers = 3
+			ctx, cancel := context.WithCancel(context.Background())
+			var mu sync.Mutex
+			calls := 0
+			image, _, err := c.RenderContext(ctx, w, func(p camera.Progress) {
+				mu.Lock()
+				defer mu.Unlock()
+				calls++
+				if p.TilesDone == 2 {
+					cancel()
+				}
+			})
+			Expect(err).To(Equal(context.Canceled))
+			Expect(image).NotTo(BeNil())
+			Expect(image.Width).To(Equal(40))
+			Expect(calls).To(BeNumerically("<", 12))
+		})
+
+		It("returns immediately for an already cancelled context", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			image, _, err := c.RenderContext(ctx, w, nil)
+			Expect(err).To(Equal(context.Canceled))
+			Expect(image.Pixel(20, 15)).To(color.Equal(color.New(0, 0, 0)))
+		})
+	})
+
+	Context("anti-aliasing", func() {
+		var (
+			w *world.World
+			c camera.Camera
+		)
+
+		render := func(c camera.Camera) *canvas.Canvas {
+			return c.Render(w)
+		}
+
+		BeforeEach(func() {
+			w = world.Default()
+			c = camera.New(21, 21, math.Pi/3)
+			c.SetTransform(matrix.ViewTransformation(
+				tuple.Point(0, 0, -5),
+				tuple.Point(0, 0, 0),
+				tuple.Vector(0, 1, 0),
+			))
+		})
+
+		It("shoots the default ray through the pixel centre", func() {
+			Expect(c.RayForPixelOffset(3, 7, 0.5, 0.5)).To(Equal(c.RayForPixel(3, 7)))
+			Expect(c.Sampling).To(Equal(camera.SampleCentre))
+		})
+
+		It("can aim rays anywhere within a pixel", func() {
+			c := camera.New(200, 100, math.Pi/2)
+			r := c.RayForPixelOffset(0, 0, 0, 0)
+			Expect(r.Direction).To(tuple.Equal(tuple.Vector(1, 0.5, -1).Normalize()))
+		})
+
+		DescribeTable("softening the silhouette of the sphere", func(sampling camera.Sampling) {
+			single := render(c)
+			c.Sampling = sampling
+			c.Samples = 4
+			multi := render(c)
+
+			black := color.New(0, 0, 0)
+			blended := 0
+			for y := 0; y < c.VSize; y++ {
+				for x := 0; x < c.HSize; x++ {
+					if single.Pixel(x, y).Equals(black) && !multi.Pixel(x, y).Equals(black) {
+						blended++
+					}
+				}
+			}
+			Expect(blended).To(BeNumerically(">", 0))
+		},
+
+			Entry("regular grid", camera.SampleGrid),
+			Entry("jittered", camera.SampleJittered),
+			Entry("random", camera.SampleRandom),
+		)
+
+		It("is reproducible for a given seed", func() {
+			c.Sampling = camera.SampleJittered
+			c.Samples = 2
+			c.Seed = 42
+			first := render(c)
+
+			c.Workers = 3
+			c.TileSize = 5
+			second := render(c)
+
+			c.Seed = 43
+			third := render(c)
+
+			same, different := true, false
+			for y := 0; y < c.VSize; y++ {
+				for x := 0; x < c.HSize; x++ {
+					same = same && first.Pixel(x, y) == second.Pixel(x, y)
+					different = different || first.Pixel(x, y) != third.Pixel(x, y)
+				}
+			}
+			Expect(same).To(BeTrue())
+			Expect(different).To(BeTrue())
+		})
+	})
+
+	Context("adaptive anti-aliasing", func() {
+		var (
+			w *world.World
+			c camera.Camera
+		)
+
+		renderWithRays := func(c camera.Camera) (*canvas.Canvas, camera.Progress, camera.Stats) {
+			var last camera.Progress
+			image, stats, err := c.RenderContext(context.Background(), w, func(p camera.Progress) {
+				last = p
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stats.ExtraRays).To(Equal(last.ExtraRays))
+			return image, last, stats
+		}
+
+		BeforeEach(func() {
+			w = world.Default()
+			c = camera.New(21, 21, math.Pi/3)
+			c.SetTransform(matrix.ViewTransformation(
+				tuple.Point(0, 0, -5),
+				tuple.Point(0, 0, 0),
+				tuple.Vector(0, 1, 0),
+			))
+			c.TileSize = 8
+		})
+
+		It("matches a single sample render when nothing contrasts enough", func() {
+			single := c.Render(w)
+			c.Sampling = camera.SampleAdaptive
+			c.AdaptiveThreshold = 10
+			image, progress, stats := renderWithRays(c)
+
+			Expect(stats.ExtraRays).To(Equal(0))
+			Expect(stats.RefinedPixels).To(Equal(0))
+			Expect(progress.TilesTotal).To(Equal(18))
+			for y := 0; y < c.VSize; y++ {
+				for x := 0; x < c.HSize; x++ {
+					Expect(image.Pixel(x, y)).To(Equal(single.Pixel(x, y)))
+				}
+			}
+		})
+
+		It("only spends extra rays on high contrast pixels", func() {
+			single := c.Render(w)
+			c.Sampling = camera.SampleAdaptive
+			c.AdaptiveThreshold = 0.1
+			c.AdaptiveDepth = 2
+			image, _, stats := renderWithRays(c)
+
+			refined := 0
+			for y := 0; y < c.VSize; y++ {
+				for x := 0; x < c.HSize; x++ {
+					if image.Pixel(x, y) != single.Pixel(x, y) {
+						refined++
+					}
+				}
+			}
+			Expect(refined).To(BeNumerically(">", 0))
+			Expect(refined).To(BeNumerically("<", c.HSize*c.VSize/2))
+			Expect(stats.RefinedPixels).To(BeNumerically(">=", refined))
+			Expect(stats.ExtraRays).To(BeNumerically(">=", 4*stats.RefinedPixels))
+
+			// a full 4x4 supersample of every pixel costs far more
+			Expect(stats.ExtraRays).To(BeNumerically("<", 16*c.HSize*c.VSize))
+			Expect(image.Pixel(0, 0)).To(Equal(single.Pixel(0, 0)))
+		})
+
+		It("gives the same result in parallel", func() {
+			c.Sampling = camera.SampleAdaptive
+			serial, _, serialStats := renderWithRays(c)
+			c.Workers = 4
+			parallel, _, parallelStats := renderWithRays(c)
+
+			Expect(parallelStats.ExtraRays).To(Equal(serialStats.ExtraRays))
+			Expect(parallelStats.RefinedPixels).To(Equal(serialStats.RefinedPixels))
+			for y := 0; y < c.VSize; y++ {
+				for x := 0; x < c.HSize; x++ {
+					Expect(parallel.Pixel(x, y)).To(Equal(serial.Pixel(x, y)))
+				}
+			}
+		})
+	})
+
+	Context("depth of field", func() {
+		var (
+			w *world.World
+			c camera.Camera
+		)
+
+		BeforeEach(func() {
+			w = world.Default()
+			c = camera.New(21, 21, math.Pi/3)
+			c.SetTransform(matrix.ViewTransformation(
+				tuple.Point(0, 0, -5),
+				tuple.Point(0, 0, 0),
+				tuple.Vector(0, 1, 0),
+			))
+		})
+
+		It("is a pinhole camera by default", func() {
+			Expect(c.Aperture).To(BeZero())
+			Expect(c.RayForPixelLens(3, 7, 0.5, 0.5, 1, 0)).To(Equal(c.RayForPixel(3, 7)))
+		})
+
+		It("ignores the focal distance without an aperture", func() {
+			pinhole := c.RayForPixel(2, 2)
+			for _, f := range []float64{0, -3, 7} {
+				c.FocalDistance = f
+				r := c.RayForPixel(2, 2)
+				Expect(r.Origin).To(tuple.Equal(pinhole.Origin))
+				Expect(r.Direction).To(tuple.Equal(pinhole.Direction))
+			}
+		})
+
+		It("treats a focal distance of zero or less as 1", func() {
+			c.Aperture = 0.5
+			c.FocalDistance = 1
+			expected := c.RayForPixelLens(2, 2, 0.5, 0.5, 1, 0)
+			for _, f := range []float64{0, -3} {
+				c.FocalDistance = f
+				r := c.RayForPixelLens(2, 2, 0.5, 0.5, 1, 0)
+				Expect(r.Origin).To(tuple.Equal(expected.Origin))
+				Expect(r.Direction).To(tuple.Equal(expected.Direction))
+			}
+		})
+
+		It("starts rays on the lens and aims them at the focal plane", func() {
+			c := camera.New(201, 101, math.Pi/2)
+			c.Aperture = 1
+			c.FocalDistance = 5
+			r := c.RayForPixelLens(100, 50, 0.5, 0.5, 1, 0)
+			Expect(r.Origin).To(tuple.Equal(tuple.Point(0.5, 0, 0)))
+			Expect(r.Direction).To(tuple.Equal(tuple.Vector(-0.5, 0, -5).Normalize()))
+		})
+
+		It("converges rays from anywhere on the lens", func() {
+			c.Aperture = 2
+			c.FocalDistance = 3
+			pinhole := c.RayForPixelOffset(4, 15, 0.3, 0.8)
+			focus := pinhole.Position(3 / pinhole.Direction.Dot(tuple.Vector(0, 0, 1)))
+			for _, lens := range [][2]float64{{-1, 0}, {0, 1}, {0.3, -0.6}} {
+				r := c.RayForPixelLens(4, 15, 0.3, 0.8, lens[0], lens[1])
+				Expect(r.Origin).NotTo(tuple.Equal(pinhole.Origin))
+				t := (focus.Z - r.Origin.Z) / r.Direction.Z
+				Expect(r.Position(t)).To(tuple.Equal(focus))
+			}
+		})
+
+		It("blurs the sphere more when it is out of focus", func() {
+			pinhole := c.Render(w)
+			c.Aperture = 0.5
+			c.Samples = 4
+
+			blurred := func(focalDistance float64) int {
+				c.FocalDistance = focalDistance
+				image := c.Render(w)
+				count := 0
+				for y := 0; y < c.VSize; y++ {
+					for x := 0; x < c.HSize; x++ {
+						if !image.Pixel(x, y).Equals(pinhole.Pixel(x, y)) {
+							count++
+						}
+					}
+				}
+				return count
+			}
+
+			Expect(blurred(4.9)).To(BeNumerically("<", blurred(20)))
+		})
+
+		It("is reproducible for a given seed alongside anti-aliasing", func() {
+			c.Aperture = 0.5
+			c.FocalDistance = 4
+			c.Sampling = camera.SampleJittered
+			c.Samples = 2
+			c.Seed = 7
+			first := c.Render(w)
+
+			c.Workers = 3
+			second := c.Render(w)
+
+			c.Seed = 8
+			third := c.Render(w)
+
+			same, different := true, false
+			for y := 0; y < c.VSize; y++ {
+				for x := 0; x < c.HSize; x++ {
+					same = same && first.Pixel(x, y) == second.Pixel(x, y)
+					different = different || first.Pixel(x, y) != third.Pixel(x, y)
+				}
+			}
+			Expect(same).To(BeTrue())
+			Expect(different).To(BeTrue())
+		})
+	})
+
+	Context("orthographic projection", func() {
+		It("sizes pixels from the view size", func() {
+			c := camera.NewOrthographic(200, 125, 10)
+			Expect(c.Projection).To(Equal(camera.ProjectionOrthographic))
+			Expect(c.HalfWidth).To(BeNumerically("~", 5))
+			Expect(c.HalfHeight).To(BeNumerically("~", 3.125))
+			Expect(c.PixelSize).To(BeNumerically("~", 0.05))
+
+			c = camera.NewOrthographic(125, 200, 10)
+			Expect(c.HalfWidth).To(BeNumerically("~", 3.125))
+			Expect(c.HalfHeight).To(BeNumerically("~", 5))
+		})
+
+		It("shoots parallel rays from the view plane", func() {
+			c := camera.NewOrthographic(201, 101, 2.01)
+			r := c.RayForPixel(100, 50)
+			Expect(r.Origin).To(tuple.Equal(tuple.Point(0, 0, 0)))
+			Expect(r.Direction).To(tuple.Equal(tuple.Vector(0, 0, -1)))
+
+			r = c.RayForPixel(0, 0)
+			Expect(r.Origin).To(tuple.Equal(tuple.Point(1, 0.5, 0)))
+			Expect(r.Direction).To(tuple.Equal(tuple.Vector(0, 0, -1)))
+		})
+
+		It("works when the camera is transformed", func() {
+			c := camera.NewOrthographic(201, 101, 2.01)
+			c.SetTransform(matrix.Identity(4, 4).Translate(0, -2, 5).RotateY(math.Pi / 4))
+			r := c.RayForPixel(0, 0)
+			r2 := math.Sqrt(2)
+			Expect(r.Origin).To(tuple.Equal(tuple.Point(r2/2, 2.5, r2/2-5)))
+			Expect(r.Direction).To(tuple.Equal(tuple.Vector(r2/2, 0, -r2/2)))
+		})
+
+		It("doesn't shrink objects with distance", func() {
+			w := world.Default()
+			c := camera.NewOrthographic(21, 21, 3)
+			c.SetTransform(matrix.ViewTransformation(
+				tuple.Point(0, 0, -5),
+				tuple.Point(0, 0, 0),
+				tuple.Vector(0, 1, 0),
+			))
+			near := c.Render(w)
+
+			c.SetTransform(matrix.ViewTransformation(
+				tuple.Point(0, 0, -50),
+				tuple.Point(0, 0, 0),
+				tuple.Vector(0, 1, 0),
+			))
+			far := c.Render(w)
+
+			for y := 0; y < c.VSize; y++ {
+				for x := 0; x < c.HSize; x++ {
+					Expect(far.Pixel(x, y)).To(color.Equal(near.Pixel(x, y)))
+				}
+			}
+			Expect(near.Pixel(0, 0)).To(color.Equal(color.New(0, 0, 0)))
+			Expect(near.Pixel(10, 10)).NotTo(color.Equal(color.New(0, 0, 0)))
+		})
+	})
+
+	Context("wide projections", func() {
+		It("maps a panorama's longitude across and latitude down", func() {
+			c := camera.NewPanoramic(360, 180)
+			Expect(c.Projection).To(Equal(camera.ProjectionPanoramic))
+
+			Expect(c.RayForPixelOffset(180, 90, 0, 0).Direction).To(tuple.Equal(tuple.Vector(0, 0, -1)))
+			Expect(c.RayForPixelOffset(90, 90, 0, 0).Direction).To(tuple.Equal(tuple.Vector(1, 0, 0)))
+			Expect(c.RayForPixelOffset(270, 90, 0, 0).Direction).To(tuple.Equal(tuple.Vector(-1, 0, 0)))
+			Expect(c.RayForPixelOffset(0, 90, 0, 0).Direction).To(tuple.Equal(tuple.Vector(0, 0, 1)))
+			Expect(c.RayForPixelOffset(180, 0, 0, 0).Direction).To(tuple.Equal(tuple.Vector(0, 1, 0)))
+			Expect(c.RayForPixelOffset(180, 45, 0, 0).Direction).To(tuple.Equal(tuple.Vector(0, math.Sqrt(2)/2, -math.Sqrt(2)/2)))
+			Expect(c.RayForPixel(5, 7).Origin).To(tuple.Equal(tuple.Point(0, 0, 0)))
+		})
+
+		It("spreads fisheye angles evenly from the centre", func() {
+			c := camera.NewFisheye(200, 100, math.Pi)
+			Expect(c.Projection).To(Equal(camera.ProjectionFisheye))
+
+			Expect(c.RayForPixelOffset(100, 50, 0, 0).Direction).To(tuple.Equal(tuple.Vector(0, 0, -1)))
+			Expect(c.RayForPixelOffset(0, 50, 0, 0).Direction).To(tuple.Equal(tuple.Vector(1, 0, 0)))
+			Expect(c.RayForPixelOffset(50, 50, 0, 0).Direction).To(tuple.Equal(tuple.Vector(math.Sqrt(2)/2, 0, -math.Sqrt(2)/2)))
+			Expect(c.RayForPixelOffset(100, 0, 0, 0).Direction).To(tuple.Equal(tuple.Vector(0, math.Sqrt(2)/2, -math.Sqrt(2)/2)))
+
+			c = camera.NewFisheye(200, 100, 2*math.Pi)
+			Expect(c.RayForPixelOffset(0, 50, 0, 0).Direction).To(tuple.Equal(tuple.Vector(0, 0, 1)))
+		})
+
+		DescribeTable("respecting the camera transform", func(c camera.Camera) {
+			c.SetTransform(matrix.Identity(4, 4).Translate(0, -2, 5).RotateY(math.Pi / 4))
+			r := c.RayForPixelOffset(c.HSize/2, c.VSize/2, 0, 0)
+			r2 := math.Sqrt(2)
+			Expect(r.Origin).To(tuple.Equal(tuple.Point(0, 2, -5)))
+			Expect(r.Direction).To(tuple.Equal(tuple.Vector(r2/2, 0, -r2/2)))
+		},
+			Entry("panoramic", camera.NewPanoramic(200, 100)),
+			Entry("fisheye", camera.NewFisheye(200, 100, math.Pi)),
+		)
+
+		It("renders the view direction in the centre and what's behind at the edges", func() {
+			w := world.Default()
+			view := matrix.ViewTransformation(
+				tuple.Point(0, 0, -5),
+				tuple.Point(0, 0, 0),
+				tuple.Vector(0, 1, 0),
+			)
+			perspective := camera.New(21, 21, math.Pi/3)
+			perspective.SetTransform(view)
+			c := camera.NewPanoramic(43, 21)
+			c.SetTransform(view)
+
+			image := c.Render(w)
+			Expect(image.Pixel(21, 10)).To(color.Equal(perspective.Render(w).Pixel(10, 10)))
+			Expect(image.Pixel(0, 10)).To(color.Equal(color.New(0, 0, 0)))
+		})
 	})
 })
@@ -0,0 +1,59 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/tuple"
+)
+
+type Projection int
+
+const (
+	// ProjectionPerspective fans rays out from a pinhole at the camera
+	// origin, covering FieldOfView.
+	ProjectionPerspective Projection = iota
+	// ProjectionOrthographic shoots parallel rays from a rectangle ViewSize
+	// world units across, so distance has no effect on apparent size.
+	ProjectionOrthographic
+	// ProjectionPanoramic covers every direction with an equirectangular
+	// mapping, for environment maps and VR previews.
+	ProjectionPanoramic
+	// ProjectionFisheye is an equidistant fisheye covering FieldOfView.
+	ProjectionFisheye
+)
+
+// panoramicDirection maps image position (x, y), in pixels, to a camera
+// space direction. As with the other projections the camera looks down -z
+// and the image's left is +x.
+func (c Camera) panoramicDirection(x, y float64) tuple.Tuple {
+	longitude := (x/float64(c.HSize) - 0.5) * 2 * math.Pi
+	latitude := (0.5 - y/float64(c.VSize)) * math.Pi
+	return tuple.Vector(
+		-math.Sin(longitude)*math.Cos(latitude),
+		math.Sin(latitude),
+		-math.Cos(longitude)*math.Cos(latitude),
+	)
+}
+
+// fisheyeDirection treats (x, y) as an angle off the view direction,
+// scaled in radians, and its bearing around it.
+func fisheyeDirection(x, y float64) tuple.Tuple {
+	theta := math.Hypot(x, y)
+	if theta == 0 {
+		return tuple.Vector(0, 0, -1)
+	}
+	s := math.Sin(theta) / theta
+	return tuple.Vector(x*s, y*s, -math.Cos(theta))
+}
+
+// lensOffset places (u, v) on a lens perpendicular to dir, which matters
+// for the wide projections where rays can leave sideways or backwards.
+func lensOffset(dir tuple.Tuple, u, v float64) tuple.Tuple {
+	up := tuple.Vector(0, 1, 0)
+	if math.Abs(dir.Y) > 0.999 {
+		up = tuple.Vector(1, 0, 0)
+	}
+	ex := up.Cross(dir).Normalize()
+	ey := dir.Cross(ex)
+	return ex.Multiply(u).Add(ey.Multiply(v))
+}
@@ -0,0 +1,199 @@
+package camera
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kieron-pivotal/rays/canvas"
+	"github.com/kieron-pivotal/rays/world"
+)
+
+type Progress struct {
+	TilesDone  int
+	TilesTotal int
+	ExtraRays  int
+	Elapsed    time.Duration
+	ETA        time.Duration
+}
+
+type ProgressFunc func(Progress)
+
+// Stats describes a finished render. ExtraRays and RefinedPixels count the
+// work adaptive sampling did beyond one ray per pixel, for tuning
+// AdaptiveThreshold and AdaptiveDepth.
+type Stats struct {
+	ExtraRays     int
+	RefinedPixels int
+	Elapsed       time.Duration
+}
+
+type tile struct {
+	x0, y0 int
+	x1, y1 int
+}
+
+type renderRun struct {
+	ctx       context.Context
+	progress  ProgressFunc
+	start     time.Time
+	total     int
+	mu        sync.Mutex
+	done      int
+	extraRays int
+	refined   int
+}
+
+func (c Camera) Render(w *world.World) *canvas.Canvas {
+	image, _, _ := c.RenderContext(context.Background(), w, nil)
+	return image
+}
+
+// RenderContext stops at the next tile or row boundary once ctx is done,
+// returning whatever has been rendered so far along with ctx's error.
+// progress, if given, is called after each tile and never concurrently.
+// Adaptive sampling makes two passes over the tiles, and counts the rays
+// cast beyond the first sample per pixel as ExtraRays.
+func (c Camera) RenderContext(ctx context.Context, w *world.World, progress ProgressFunc) (*canvas.Canvas, Stats, error) {
+	image := canvas.New(c.HSize, c.VSize)
+	w = w.WithBVH()
+
+	tiles := c.tiles()
+	run := &renderRun{
+		ctx:      ctx,
+		progress: progress,
+		start:    time.Now(),
+		total:    len(tiles),
+	}
+	if c.Sampling != SampleAdaptive {
+		c.eachTile(run, tiles, func(t tile) bool {
+			return c.renderTile(ctx, w, image, t)
+		})
+		return image, run.stats(), ctx.Err()
+	}
+
+	run.total *= 2
+	first := canvas.New(c.HSize, c.VSize)
+	c.eachTile(run, tiles, func(t tile) bool {
+		return c.renderTile(ctx, w, first, t)
+	})
+	if ctx.Err() != nil {
+		return first, run.stats(), ctx.Err()
+	}
+	for y := 0; y < c.VSize; y++ {
+		for x := 0; x < c.HSize; x++ {
+			image.SetPixel(x, y, first.Pixel(x, y))
+		}
+	}
+	c.eachTile(run, tiles, func(t tile) bool {
+		return c.refineTile(run, w, first, image, t)
+	})
+	return image, run.stats(), ctx.Err()
+}
+
+func (c Camera) eachTile(run *renderRun, tiles []tile, render func(tile) bool) {
+	workers := c.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	queue := make(chan tile)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range queue {
+				if render(t) {
+					run.tileDone()
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, t := range tiles {
+		select {
+		case queue <- t:
+		case <-run.ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+}
+
+func (r *renderRun) tileDone() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.done++
+	if r.progress == nil {
+		return
+	}
+	elapsed := time.Since(r.start)
+	eta := time.Duration(float64(elapsed) / float64(r.done) * float64(r.total-r.done))
+	r.progress(Progress{
+		TilesDone:  r.done,
+		TilesTotal: r.total,
+		ExtraRays:  r.extraRays,
+		Elapsed:    elapsed,
+		ETA:        eta,
+	})
+}
+
+func (r *renderRun) addRefined(pixels, rays int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refined += pixels
+	r.extraRays += rays
+}
+
+func (r *renderRun) stats() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Stats{
+		ExtraRays:     r.extraRays,
+		RefinedPixels: r.refined,
+		Elapsed:       time.Since(r.start),
+	}
+}
+
+// Each pixel belongs to exactly one tile, so workers never write to the
+// same canvas cell and need no locking.
+func (c Camera) tiles() []tile {
+	size := c.TileSize
+	if size < 1 {
+		size = 1
+	}
+	tiles := []tile{}
+	for y := 0; y < c.VSize; y += size {
+		for x := 0; x < c.HSize; x += size {
+			tiles = append(tiles, tile{
+				x0: x,
+				y0: y,
+				x1: minInt(x+size, c.HSize),
+				y1: minInt(y+size, c.VSize),
+			})
+		}
+	}
+	return tiles
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (c Camera) renderTile(ctx context.Context, w *world.World, image *canvas.Canvas, t tile) bool {
+	for py := t.y0; py < t.y1; py++ {
+		if ctx.Err() != nil {
+			return false
+		}
+		for px := t.x0; px < t.x1; px++ {
+			image.SetPixel(px, py, c.pixelColor(w, px, py))
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/color"
+	"github.com/kieron-pivotal/rays/internal/splitmix"
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/world"
+)
+
+type Sampling int
+
+const (
+	// SampleCentre casts a single ray through the middle of each pixel.
+	SampleCentre Sampling = iota
+	// SampleGrid casts Samples x Samples rays on a regular sub-pixel grid.
+	SampleGrid
+	// SampleJittered casts one ray at a random point in each grid cell.
+	SampleJittered
+	// SampleRandom casts Samples x Samples rays anywhere in the pixel.
+	SampleRandom
+	// SampleAdaptive casts one ray per pixel, then subdivides pixels that
+	// contrast with their neighbours by more than AdaptiveThreshold, up to
+	// AdaptiveDepth times.
+	SampleAdaptive
+)
+
+// pixelOffsets returns the sub-pixel points to shoot rays through. The
+// random strategies draw from rnd, which is seeded from Seed and the pixel
+// position so that the result doesn't depend on render order.
+func (c Camera) pixelOffsets(rnd *splitmix.Rand) [][2]float64 {
+	n := c.Samples
+	if n < 1 {
+		return [][2]float64{{0.5, 0.5}}
+	}
+	if c.Sampling == SampleCentre || c.Sampling == SampleAdaptive {
+		if c.Aperture <= 0 {
+			return [][2]float64{{0.5, 0.5}}
+		}
+		// a single ray can't average over the lens, so take Samples x
+		// Samples lens samples through the centre instead
+		offsets := make([][2]float64, n*n)
+		for i := range offsets {
+			offsets[i] = [2]float64{0.5, 0.5}
+		}
+		return offsets
+	}
+
+	offsets := make([][2]float64, 0, n*n)
+	step := 1.0 / float64(n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			var dx, dy float64
+			switch c.Sampling {
+			case SampleGrid:
+				dx, dy = (float64(i)+0.5)*step, (float64(j)+0.5)*step
+			case SampleJittered:
+				dx, dy = (float64(i)+rnd.Float64())*step, (float64(j)+rnd.Float64())*step
+			default:
+				dx, dy = rnd.Float64(), rnd.Float64()
+			}
+			offsets = append(offsets, [2]float64{dx, dy})
+		}
+	}
+	return offsets
+}
+
+func (c Camera) pixelColor(w *world.World, px, py int) color.Color {
+	rnd := newPixelRand(c.Seed, px, py)
+	offsets := c.pixelOffsets(rnd)
+	sum := color.New(0, 0, 0)
+	for _, o := range offsets {
+		sum = sum.Add(w.ColorAt(c.sampleRay(rnd, px, py, o[0], o[1])))
+	}
+	return sum.Multiply(1 / float64(len(offsets)))
+}
+
+// sampleRay is RayForPixelOffset from a random point on the lens when the
+// camera has an aperture.
+func (c Camera) sampleRay(rnd *splitmix.Rand, px, py int, dx, dy float64) ray.Ray {
+	if c.Aperture <= 0 {
+		return c.RayForPixelOffset(px, py, dx, dy)
+	}
+	lu, lv := diskPoint(rnd)
+	return c.RayForPixelLens(px, py, dx, dy, lu, lv)
+}
+
+// newPixelRand seeds a generator from Seed and the pixel position, so the
+// result doesn't depend on render order.
+func newPixelRand(seed int64, px, py int) *splitmix.Rand {
+	return splitmix.New(uint64(seed) ^ (uint64(px)*0x9e3779b97f4a7c15 + uint64(py)*0xc2b2ae3d27d4eb4f))
+}
+
+// diskPoint returns a point uniformly distributed over the unit disk.
+func diskPoint(rnd *splitmix.Rand) (float64, float64) {
+	radius := math.Sqrt(rnd.Float64())
+	theta := 2 * math.Pi * rnd.Float64()
+	return radius * math.Cos(theta), radius * math.Sin(theta)
+}
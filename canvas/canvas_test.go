@@ -1,6 +1,8 @@
 package canvas_test
 
 import (
+	"bytes"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -73,5 +75,31 @@ var _ = Describe("Canvas", func() {
 `
 			Expect(ppm).To(HaveSuffix(expectedData))
 		})
+
+		It("ends with a newline", func() {
+			c := canvas.New(5, 3)
+			Expect(c.ToPPM()).To(HaveSuffix("\n"))
+		})
+
+		It("can stream plain PPM to a writer", func() {
+			c := canvas.New(10, 2)
+			c.SetPixel(3, 1, color.New(1, 0.8, 0.6))
+			var buf bytes.Buffer
+			Expect(c.WritePPM(&buf, canvas.PPMPlain)).To(Succeed())
+			Expect(buf.String()).To(Equal(c.ToPPM()))
+		})
+
+		It("can stream binary PPM to a writer", func() {
+			c := canvas.New(2, 2)
+			c.SetPixel(0, 0, color.New(1.5, 0, 0))
+			c.SetPixel(1, 1, color.New(0, 0.5, 1))
+			var buf bytes.Buffer
+			Expect(c.WritePPM(&buf, canvas.PPMBinary)).To(Succeed())
+			expected := append([]byte("P6\n2 2\n255\n"),
+				255, 0, 0, 0, 0, 0,
+				0, 0, 0, 0, 127, 255,
+			)
+			Expect(buf.Bytes()).To(Equal(expected))
+		})
 	})
 })
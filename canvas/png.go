@@ -0,0 +1,27 @@
+package canvas
+
+import (
+	"image"
+	stdcolor "image/color"
+	"image/png"
+	"io"
+)
+
+func (c *Canvas) Image() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, c.Width, c.Height))
+	for y, row := range c.pixels {
+		for x, p := range row {
+			img.SetNRGBA(x, y, stdcolor.NRGBA{
+				R: uint8(to255(p.Red())),
+				G: uint8(to255(p.Green())),
+				B: uint8(to255(p.Blue())),
+				A: 255,
+			})
+		}
+	}
+	return img
+}
+
+func (c *Canvas) WritePNG(w io.Writer) error {
+	return png.Encode(w, c.Image())
+}
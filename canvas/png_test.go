@@ -0,0 +1,47 @@
+package canvas_test
+
+import (
+	"bytes"
+	stdcolor "image/color"
+	"image/png"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/kieron-pivotal/rays/canvas"
+	"github.com/kieron-pivotal/rays/color"
+)
+
+var _ = Describe("PNG", func() {
+	var (
+		c *canvas.Canvas
+	)
+
+	BeforeEach(func() {
+		c = canvas.New(5, 3)
+		c.SetPixel(0, 0, color.New(1.5, 0, 0))
+		c.SetPixel(2, 1, color.New(0, 0.5, 0))
+		c.SetPixel(4, 2, color.New(-0.5, 0, 1))
+	})
+
+	It("exposes the canvas as an image with clamped colours", func() {
+		img := c.Image()
+		Expect(img.Bounds().Dx()).To(Equal(5))
+		Expect(img.Bounds().Dy()).To(Equal(3))
+		Expect(img.At(0, 0)).To(Equal(stdcolor.NRGBA{R: 255, G: 0, B: 0, A: 255}))
+		Expect(img.At(2, 1)).To(Equal(stdcolor.NRGBA{R: 0, G: 127, B: 0, A: 255}))
+		Expect(img.At(4, 2)).To(Equal(stdcolor.NRGBA{R: 0, G: 0, B: 255, A: 255}))
+		Expect(img.At(1, 1)).To(Equal(stdcolor.NRGBA{R: 0, G: 0, B: 0, A: 255}))
+	})
+
+	It("encodes to PNG", func() {
+		var buf bytes.Buffer
+		Expect(c.WritePNG(&buf)).To(Succeed())
+
+		img, err := png.Decode(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(img.Bounds().Dx()).To(Equal(5))
+		r, g, b, a := img.At(2, 1).RGBA()
+		Expect([]uint32{r >> 8, g >> 8, b >> 8, a >> 8}).To(Equal([]uint32{0, 127, 0, 255}))
+	})
+})
@@ -0,0 +1,90 @@
+package canvas
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type PPMFormat int
+
+const (
+	PPMPlain PPMFormat = iota
+	PPMBinary
+)
+
+const ppmMaxLineLength = 70
+
+func (c *Canvas) ToPPM() string {
+	var sb strings.Builder
+	c.WritePPM(&sb, PPMPlain)
+	return sb.String()
+}
+
+// WritePPM streams the canvas a row at a time, as P3 text for PPMPlain or
+// P6 bytes for PPMBinary.
+func (c *Canvas) WritePPM(w io.Writer, format PPMFormat) error {
+	bw := bufio.NewWriter(w)
+
+	magic := "P3"
+	if format == PPMBinary {
+		magic = "P6"
+	}
+	if _, err := fmt.Fprintf(bw, "%s\n%d %d\n255\n", magic, c.Width, c.Height); err != nil {
+		return err
+	}
+
+	var err error
+	if format == PPMBinary {
+		err = c.writeP6Pixels(bw)
+	} else {
+		err = c.writeP3Pixels(bw)
+	}
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func (c *Canvas) writeP3Pixels(w *bufio.Writer) error {
+	buf := make([]byte, 0, 4)
+	for _, row := range c.pixels {
+		lineLen := 0
+		for _, p := range row {
+			for _, v := range []float64{p.Red(), p.Green(), p.Blue()} {
+				buf = strconv.AppendInt(buf[:0], int64(to255(v)), 10)
+				if lineLen > 0 && lineLen+1+len(buf) > ppmMaxLineLength {
+					w.WriteByte('\n')
+					lineLen = 0
+				}
+				if lineLen > 0 {
+					w.WriteByte(' ')
+					lineLen++
+				}
+				w.Write(buf)
+				lineLen += len(buf)
+			}
+		}
+		if err := w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Canvas) writeP6Pixels(w *bufio.Writer) error {
+	buf := make([]byte, 3*c.Width)
+	for _, row := range c.pixels {
+		for x, p := range row {
+			buf[3*x] = byte(to255(p.Red()))
+			buf[3*x+1] = byte(to255(p.Green()))
+			buf[3*x+2] = byte(to255(p.Blue()))
+		}
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
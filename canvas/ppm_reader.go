@@ -0,0 +1,158 @@
+package canvas
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/kieron-pivotal/rays/color"
+)
+
+// MaxPPMPixels caps the size of image ReadPPM will accept, so that a bad
+// or hostile header can't make it allocate a huge canvas before finding
+// out the pixel data isn't there.
+var MaxPPMPixels = 1 << 24
+
+func ReadPPM(r io.Reader) (*Canvas, error) {
+	br := bufio.NewReader(r)
+
+	magic, err := readPPMToken(br)
+	if err != nil {
+		return nil, fmt.Errorf("ppm: reading magic number: %s", err)
+	}
+	if magic != "P3" && magic != "P6" {
+		return nil, fmt.Errorf("ppm: unsupported magic number %q", magic)
+	}
+
+	header := [3]int{}
+	for i, name := range []string{"width", "height", "max value"} {
+		header[i], err = readPPMInt(br, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	width, height, maxVal := header[0], header[1], header[2]
+	if width < 1 || height < 1 {
+		return nil, fmt.Errorf("ppm: invalid size %dx%d", width, height)
+	}
+	if width > MaxPPMPixels || height > MaxPPMPixels/width {
+		return nil, fmt.Errorf("ppm: size %dx%d is over the limit of %d pixels", width, height, MaxPPMPixels)
+	}
+	if maxVal < 1 || maxVal > 65535 {
+		return nil, fmt.Errorf("ppm: max value %d out of range 1..65535", maxVal)
+	}
+
+	c := New(width, height)
+	if magic == "P3" {
+		err = readP3Pixels(br, c, maxVal)
+	} else {
+		err = readP6Pixels(br, c, maxVal)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func readP3Pixels(br *bufio.Reader, c *Canvas, maxVal int) error {
+	scale := float64(maxVal)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			rgb := [3]float64{}
+			for i := range rgb {
+				v, err := readPPMInt(br, fmt.Sprintf("pixel (%d, %d)", x, y))
+				if err != nil {
+					return err
+				}
+				if v > maxVal {
+					return fmt.Errorf("ppm: pixel (%d, %d) value %d exceeds max value %d", x, y, v, maxVal)
+				}
+				rgb[i] = float64(v) / scale
+			}
+			c.SetPixel(x, y, color.New(rgb[0], rgb[1], rgb[2]))
+		}
+	}
+	return nil
+}
+
+func readP6Pixels(br *bufio.Reader, c *Canvas, maxVal int) error {
+	bytesPerValue := 1
+	if maxVal > 255 {
+		bytesPerValue = 2
+	}
+	scale := float64(maxVal)
+
+	// exactly one whitespace byte separates the header from the data
+	if _, err := br.ReadByte(); err != nil {
+		return fmt.Errorf("ppm: missing pixel data: %s", err)
+	}
+
+	row := make([]byte, 3*bytesPerValue*c.Width)
+	for y := 0; y < c.Height; y++ {
+		if _, err := io.ReadFull(br, row); err != nil {
+			return fmt.Errorf("ppm: reading row %d: %s", y, err)
+		}
+		for x := 0; x < c.Width; x++ {
+			rgb := [3]float64{}
+			for i := range rgb {
+				offset := (3*x + i) * bytesPerValue
+				v := int(row[offset])
+				if bytesPerValue == 2 {
+					v = v<<8 | int(row[offset+1])
+				}
+				rgb[i] = float64(v) / scale
+			}
+			c.SetPixel(x, y, color.New(rgb[0], rgb[1], rgb[2]))
+		}
+	}
+	return nil
+}
+
+func readPPMInt(br *bufio.Reader, name string) (int, error) {
+	tok, err := readPPMToken(br)
+	if err != nil {
+		return 0, fmt.Errorf("ppm: reading %s: %s", name, err)
+	}
+	v, err := strconv.Atoi(tok)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("ppm: %s %q is not a valid number", name, tok)
+	}
+	return v, nil
+}
+
+// readPPMToken skips whitespace and comments, then reads up to but not
+// including the next whitespace byte.
+func readPPMToken(br *bufio.Reader) (string, error) {
+	tok := []byte{}
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF && len(tok) > 0 {
+			return string(tok), nil
+		}
+		if err == io.EOF {
+			return "", io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case b == '#' && len(tok) == 0:
+			if _, err := br.ReadString('\n'); err != nil && err != io.EOF {
+				return "", err
+			}
+		case isPPMSpace(b):
+			if len(tok) > 0 {
+				br.UnreadByte()
+				return string(tok), nil
+			}
+		default:
+			tok = append(tok, b)
+		}
+	}
+}
+
+func isPPMSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f'
+}
@@ -0,0 +1,128 @@
+package canvas_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/kieron-pivotal/rays/canvas"
+	"github.com/kieron-pivotal/rays/color"
+)
+
+var _ = Describe("Reading PPM", func() {
+
+	read := func(s string) *canvas.Canvas {
+		c, err := canvas.ReadPPM(strings.NewReader(s))
+		Expect(err).NotTo(HaveOccurred())
+		return c
+	}
+
+	It("reads the size from the header", func() {
+		c := read("P3\n10 2\n255\n" + strings.Repeat("0 0 0\n", 20))
+		Expect(c.Width).To(Equal(10))
+		Expect(c.Height).To(Equal(2))
+	})
+
+	DescribeTable("reading pixel data", func(x, y int, expected color.Color) {
+		c := read(`P3
+4 3
+255
+255 127 0  0 127 255  127 255 0  255 255 255
+0 0 0  255 0 0  0 255 0  0 0 255
+255 255 0  0 255 255  255 0 255  127 127 127
+`)
+		Expect(c.Pixel(x, y)).To(color.Equal(expected))
+	},
+
+		Entry("0, 0", 0, 0, color.New(1, 0.49804, 0)),
+		Entry("1, 0", 1, 0, color.New(0, 0.49804, 1)),
+		Entry("2, 0", 2, 0, color.New(0.49804, 1, 0)),
+		Entry("3, 0", 3, 0, color.New(1, 1, 1)),
+		Entry("0, 1", 0, 1, color.New(0, 0, 0)),
+		Entry("1, 1", 1, 1, color.New(1, 0, 0)),
+		Entry("2, 1", 2, 1, color.New(0, 1, 0)),
+		Entry("3, 1", 3, 1, color.New(0, 0, 1)),
+		Entry("0, 2", 0, 2, color.New(1, 1, 0)),
+		Entry("1, 2", 1, 2, color.New(0, 1, 1)),
+		Entry("2, 2", 2, 2, color.New(1, 0, 1)),
+		Entry("3, 2", 3, 2, color.New(0.49804, 0.49804, 0.49804)),
+	)
+
+	It("ignores comment lines", func() {
+		c := read(`P3
+# this is a comment
+2 1
+# this, too
+255
+# another comment
+255 255 255
+# oh, no, comments in the pixel data!
+255 0 255
+`)
+		Expect(c.Pixel(0, 0)).To(color.Equal(color.New(1, 1, 1)))
+		Expect(c.Pixel(1, 0)).To(color.Equal(color.New(1, 0, 1)))
+	})
+
+	It("allows an RGB triple to span lines", func() {
+		c := read("P3\n1 1\n255\n51\n153\n\n204\n")
+		Expect(c.Pixel(0, 0)).To(color.Equal(color.New(0.2, 0.6, 0.8)))
+	})
+
+	It("respects the scale setting", func() {
+		c := read(`P3
+2 2
+100
+100 100 100  50 50 50
+75 50 25  0 0 0
+`)
+		Expect(c.Pixel(0, 1)).To(color.Equal(color.New(0.75, 0.5, 0.25)))
+	})
+
+	It("reads binary P6 data", func() {
+		data := append([]byte("P6\n# binary\n2 1\n255\n"), 255, 0, 51, 0, 153, 204)
+		c, err := canvas.ReadPPM(bytes.NewReader(data))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Pixel(0, 0)).To(color.Equal(color.New(1, 0, 0.2)))
+		Expect(c.Pixel(1, 0)).To(color.Equal(color.New(0, 0.6, 0.8)))
+	})
+
+	It("reads 16-bit binary P6 data", func() {
+		data := append([]byte("P6 1 1 65535\n"), 0xff, 0xff, 0x80, 0x00, 0x00, 0x00)
+		c, err := canvas.ReadPPM(bytes.NewReader(data))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Pixel(0, 0)).To(color.Equal(color.New(1, 32768.0/65535.0, 0)))
+	})
+
+	It("round trips what it writes", func() {
+		c := canvas.New(3, 2)
+		c.SetPixel(0, 0, color.New(1, 0.2, 0.4))
+		c.SetPixel(2, 1, color.New(0, 1, 0.6))
+		for _, format := range []canvas.PPMFormat{canvas.PPMPlain, canvas.PPMBinary} {
+			var buf bytes.Buffer
+			Expect(c.WritePPM(&buf, format)).To(Succeed())
+			c2, err := canvas.ReadPPM(&buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c2.ToPPM()).To(Equal(c.ToPPM()))
+		}
+	})
+
+	DescribeTable("rejecting bad input", func(input, message string) {
+		_, err := canvas.ReadPPM(strings.NewReader(input))
+		Expect(err).To(MatchError(ContainSubstring(message)))
+	},
+
+		Entry("wrong magic number", "P32\n1 1\n255\n0 0 0\n", "unsupported magic number"),
+		Entry("empty input", "", "magic number"),
+		Entry("bad width", "P3\nten 1\n255\n", "width"),
+		Entry("zero size", "P3\n0 1\n255\n", "invalid size"),
+		Entry("huge size", "P6\n100000 100000\n255\n\x00\x00\x00", "over the limit"),
+		Entry("overflowing size", "P6\n4294967296 4294967296\n255\n", "over the limit"),
+		Entry("bad max value", "P3\n1 1\n0\n0 0 0\n", "max value"),
+		Entry("value too large", "P3\n1 1\n100\n0 101 0\n", "exceeds max value"),
+		Entry("truncated P3 data", "P3\n2 1\n255\n0 0 0 0\n", "pixel (1, 0)"),
+		Entry("truncated P6 data", "P6\n2 1\n255\n\x00\x00\x00", "row 0"),
+	)
+})
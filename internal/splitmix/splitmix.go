@@ -0,0 +1,25 @@
+// Package splitmix is a splitmix64 generator. It is much cheaper to seed
+// than math/rand, so the renderer can seed one per pixel or per point being
+// lit and get the same image whatever order the workers run in.
+package splitmix
+
+type Rand struct {
+	state uint64
+}
+
+func New(seed uint64) *Rand {
+	return &Rand{state: seed}
+}
+
+func (r *Rand) Uint64() uint64 {
+	r.state += 0x9e3779b97f4a7c15
+	z := r.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// Float64 returns a number in [0, 1).
+func (r *Rand) Float64() float64 {
+	return float64(r.Uint64()>>11) / (1 << 53)
+}
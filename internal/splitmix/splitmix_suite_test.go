@@ -0,0 +1,13 @@
+package splitmix_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSplitmix(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Splitmix Suite")
+}
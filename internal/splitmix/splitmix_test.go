@@ -0,0 +1,32 @@
+package splitmix_test
+
+import (
+	"github.com/kieron-pivotal/rays/internal/splitmix"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Splitmix", func() {
+	It("matches the reference sequence", func() {
+		r := splitmix.New(1234567)
+		Expect(r.Uint64()).To(Equal(uint64(6457827717110365317)))
+		Expect(r.Uint64()).To(Equal(uint64(3203168211198807973)))
+	})
+
+	It("repeats for the same seed", func() {
+		a, b := splitmix.New(42), splitmix.New(42)
+		for i := 0; i < 10; i++ {
+			Expect(a.Float64()).To(Equal(b.Float64()))
+		}
+	})
+
+	It("gives floats in [0, 1)", func() {
+		r := splitmix.New(7)
+		for i := 0; i < 1000; i++ {
+			f := r.Float64()
+			Expect(f).To(BeNumerically(">=", 0))
+			Expect(f).To(BeNumerically("<", 1))
+		}
+	})
+})
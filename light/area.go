@@ -0,0 +1,94 @@
+package light
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/color"
+	"github.com/kieron-pivotal/rays/internal/splitmix"
+	"github.com/kieron-pivotal/rays/tuple"
+)
+
+// Area is a rectangular light spanning UEdge and VEdge from Corner. It is
+// sampled on a USteps x VSteps grid, which softens the edges of shadows.
+// Step counts below 1 are taken as 1.
+type Area struct {
+	Corner    tuple.Tuple
+	UEdge     tuple.Tuple
+	VEdge     tuple.Tuple
+	USteps    int
+	VSteps    int
+	Intensity color.Color
+	// Jitter moves each sample to a random spot within its cell, trading
+	// banding in the penumbra for noise. The spots depend only on Seed and
+	// the point being lit, so renders are repeatable.
+	Jitter bool
+	Seed   int64
+}
+
+func NewArea(corner, uEdge tuple.Tuple, uSteps int, vEdge tuple.Tuple, vSteps int, intensity color.Color) Area {
+	return Area{
+		Corner:    corner,
+		UEdge:     uEdge,
+		VEdge:     vEdge,
+		USteps:    uSteps,
+		VSteps:    vSteps,
+		Intensity: intensity,
+		Jitter:    true,
+	}
+}
+
+func (l Area) GetIntensity() color.Color {
+	return l.Intensity
+}
+
+// Centre is the middle of the light.
+func (l Area) Centre() tuple.Tuple {
+	return l.Corner.Add(l.UEdge.Multiply(0.5)).Add(l.VEdge.Multiply(0.5))
+}
+
+// PointOn returns the point at (u, v) in the grid, where u and v are cell
+// positions plus an offset within the cell from 0 to 1.
+func (l Area) PointOn(u, v float64) tuple.Tuple {
+	uSteps, vSteps := l.steps()
+	return l.Corner.
+		Add(l.UEdge.Multiply(u / float64(uSteps))).
+		Add(l.VEdge.Multiply(v / float64(vSteps)))
+}
+
+func (l Area) SamplesFrom(p tuple.Tuple) []Sample {
+	rnd := newPointRand(l.Seed, p)
+	uSteps, vSteps := l.steps()
+	samples := make([]Sample, 0, uSteps*vSteps)
+	for v := 0; v < vSteps; v++ {
+		for u := 0; u < uSteps; u++ {
+			du, dv := 0.5, 0.5
+			if l.Jitter {
+				du, dv = rnd.Float64(), rnd.Float64()
+			}
+			pos := l.PointOn(float64(u)+du, float64(v)+dv)
+			samples = append(samples, sampleTowards(p, pos, l.Intensity))
+		}
+	}
+	return samples
+}
+
+func (l Area) steps() (int, int) {
+	uSteps, vSteps := l.USteps, l.VSteps
+	if uSteps < 1 {
+		uSteps = 1
+	}
+	if vSteps < 1 {
+		vSteps = 1
+	}
+	return uSteps, vSteps
+}
+
+// newPointRand seeds a generator from the point being lit, so sampling
+// needs no shared state between render workers.
+func newPointRand(seed int64, p tuple.Tuple) *splitmix.Rand {
+	state := uint64(seed)
+	for _, f := range []float64{p.X, p.Y, p.Z} {
+		state = (state ^ math.Float64bits(f)) * 0x9e3779b97f4a7c15
+	}
+	return splitmix.New(state)
+}
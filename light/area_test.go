@@ -0,0 +1,88 @@
+package light_test
+
+import (
+	"github.com/kieron-pivotal/rays/color"
+	"github.com/kieron-pivotal/rays/light"
+	"github.com/kieron-pivotal/rays/tuple"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Area light", func() {
+	var l light.Area
+
+	BeforeEach(func() {
+		l = light.NewArea(
+			tuple.Point(0, 0, 0),
+			tuple.Vector(2, 0, 0), 4,
+			tuple.Vector(0, 0, 1), 2,
+			color.New(1, 1, 1),
+		)
+	})
+
+	It("has a corner, edges, sample counts and intensity", func() {
+		Expect(l.Corner).To(tuple.Equal(tuple.Point(0, 0, 0)))
+		Expect(l.UEdge).To(tuple.Equal(tuple.Vector(2, 0, 0)))
+		Expect(l.VEdge).To(tuple.Equal(tuple.Vector(0, 0, 1)))
+		Expect(l.USteps).To(Equal(4))
+		Expect(l.VSteps).To(Equal(2))
+		Expect(l.GetIntensity()).To(color.Equal(color.New(1, 1, 1)))
+		Expect(l.Jitter).To(BeTrue())
+		Expect(l.Centre()).To(tuple.Equal(tuple.Point(1, 0, 0.5)))
+	})
+
+	DescribeTable("finding a point in a cell", func(u, v float64, expected tuple.Tuple) {
+		Expect(l.PointOn(u, v)).To(tuple.Equal(expected))
+	},
+		Entry("first cell", 0.5, 0.5, tuple.Point(0.25, 0, 0.25)),
+		Entry("second u", 1.5, 0.5, tuple.Point(0.75, 0, 0.25)),
+		Entry("second v", 0.5, 1.5, tuple.Point(0.25, 0, 0.75)),
+		Entry("third u", 2.5, 0.5, tuple.Point(1.25, 0, 0.25)),
+		Entry("last cell", 3.5, 1.5, tuple.Point(1.75, 0, 0.75)),
+	)
+
+	It("samples the centre of each cell without jitter", func() {
+		l.Jitter = false
+		p := tuple.Point(0.25, 2, 0.25)
+		samples := l.SamplesFrom(p)
+		Expect(samples).To(HaveLen(8))
+		Expect(samples[0].Direction).To(tuple.Equal(tuple.Vector(0, -1, 0)))
+		Expect(samples[0].Distance).To(BeNumerically("~", 2))
+		Expect(samples[7].Direction).To(tuple.Equal(tuple.Vector(1.5, -2, 0.5).Normalize()))
+		Expect(samples[7].Intensity).To(color.Equal(color.New(1, 1, 1)))
+	})
+
+	It("jitters each sample within its own cell", func() {
+		p := tuple.Point(0, 5, 0)
+		for i, s := range l.SamplesFrom(p) {
+			pos := p.Add(s.Direction.Multiply(s.Distance))
+			u, v := i%4, i/4
+			Expect(pos.X).To(BeNumerically(">=", float64(u)*0.5))
+			Expect(pos.X).To(BeNumerically("<", float64(u+1)*0.5))
+			Expect(pos.Z).To(BeNumerically(">=", float64(v)*0.5))
+			Expect(pos.Z).To(BeNumerically("<", float64(v+1)*0.5))
+		}
+	})
+
+	It("jitters repeatably for a given point and seed", func() {
+		p := tuple.Point(0, 5, 0)
+		first := l.SamplesFrom(p)
+		Expect(l.SamplesFrom(p)).To(Equal(first))
+		Expect(l.SamplesFrom(tuple.Point(0, 5, 0.001))[0].Direction).NotTo(tuple.Equal(first[0].Direction))
+
+		l.Seed = 3
+		Expect(l.SamplesFrom(p)[0].Direction).NotTo(tuple.Equal(first[0].Direction))
+	})
+
+	It("takes step counts below 1 as 1", func() {
+		l.USteps = 0
+		l.VSteps = -2
+		l.Jitter = false
+		samples := l.SamplesFrom(tuple.Point(1, 3, 0.5))
+		Expect(samples).To(HaveLen(1))
+		Expect(samples[0].Direction).To(tuple.Equal(tuple.Vector(0, -1, 0)))
+		Expect(samples[0].Distance).To(BeNumerically("~", 3))
+		Expect(l.PointOn(0.5, 0.5)).To(tuple.Equal(tuple.Point(1, 0, 0.5)))
+	})
+})
@@ -0,0 +1,28 @@
+package light
+
+// Attenuation dims a light with distance d by 1/(Constant + Linear*d +
+// Quadratic*d²). The zero value leaves the light undimmed at any distance.
+type Attenuation struct {
+	Constant  float64
+	Linear    float64
+	Quadratic float64
+}
+
+// InverseSquare is the physically based falloff. It brightens the light
+// within 1 unit, so scale the intensity to suit the scene.
+var InverseSquare = Attenuation{Quadratic: 1}
+
+func (a Attenuation) Factor(distance float64) float64 {
+	if a == (Attenuation{}) {
+		return 1
+	}
+	// unused terms are skipped so that an infinite distance can't make NaN
+	denominator := a.Constant
+	if a.Linear != 0 {
+		denominator += a.Linear * distance
+	}
+	if a.Quadratic != 0 {
+		denominator += a.Quadratic * distance * distance
+	}
+	return 1 / denominator
+}
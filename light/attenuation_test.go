@@ -0,0 +1,36 @@
+package light_test
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/color"
+	"github.com/kieron-pivotal/rays/light"
+	"github.com/kieron-pivotal/rays/tuple"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Attenuation", func() {
+	DescribeTable("dimming with distance", func(a light.Attenuation, distance, expected float64) {
+		Expect(a.Factor(distance)).To(BeNumerically("~", expected))
+	},
+		Entry("none by default", light.Attenuation{}, 1000.0, 1.0),
+		Entry("none to infinity", light.Attenuation{}, math.Inf(1), 1.0),
+		Entry("constant", light.Attenuation{Constant: 2}, 1000.0, 0.5),
+		Entry("linear", light.Attenuation{Constant: 1, Linear: 0.5}, 2.0, 0.5),
+		Entry("quadratic", light.Attenuation{Constant: 1, Linear: 0.5, Quadratic: 0.25}, 2.0, 1/3.0),
+		Entry("inverse square", light.InverseSquare, 4.0, 1/16.0),
+		Entry("inverse square at infinity", light.InverseSquare, math.Inf(1), 0.0),
+	)
+
+	It("is carried on point and spot light samples", func() {
+		p := light.NewPoint(tuple.Point(0, 10, 0), color.New(1, 1, 1))
+		p.Attenuation = light.InverseSquare
+		Expect(p.SamplesFrom(tuple.Point(0, 0, 0))[0].Attenuation).To(Equal(light.InverseSquare))
+
+		s := light.NewSpot(tuple.Point(0, 10, 0), tuple.Vector(0, -1, 0), 0.5, 1, color.New(1, 1, 1))
+		s.Attenuation = light.Attenuation{Linear: 1}
+		Expect(s.SamplesFrom(tuple.Point(0, 0, 0))[0].Attenuation).To(Equal(light.Attenuation{Linear: 1}))
+	})
+})
@@ -0,0 +1,34 @@
+package light
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/color"
+	"github.com/kieron-pivotal/rays/tuple"
+)
+
+// Directional is a light infinitely far away, like the sun: its rays are
+// parallel, travelling along Direction, and nothing is beyond its reach.
+type Directional struct {
+	Direction tuple.Tuple
+	Intensity color.Color
+}
+
+func NewDirectional(direction tuple.Tuple, intensity color.Color) Directional {
+	return Directional{
+		Direction: direction.Normalize(),
+		Intensity: intensity,
+	}
+}
+
+func (l Directional) GetIntensity() color.Color {
+	return l.Intensity
+}
+
+func (l Directional) SamplesFrom(p tuple.Tuple) []Sample {
+	return []Sample{{
+		Direction: l.Direction.Negate(),
+		Distance:  math.Inf(1),
+		Intensity: l.Intensity,
+	}}
+}
@@ -0,0 +1,31 @@
+package light_test
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/color"
+	"github.com/kieron-pivotal/rays/light"
+	"github.com/kieron-pivotal/rays/tuple"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Directional light", func() {
+	It("has a normalised direction and intensity", func() {
+		l := light.NewDirectional(tuple.Vector(0, -3, 0), color.New(1, 1, 0.9))
+		Expect(l.Direction).To(tuple.Equal(tuple.Vector(0, -1, 0)))
+		Expect(l.GetIntensity()).To(color.Equal(color.New(1, 1, 0.9)))
+	})
+
+	It("lights every point from the same direction, infinitely far away", func() {
+		l := light.NewDirectional(tuple.Vector(1, -1, 0), color.New(1, 1, 1))
+		r2 := math.Sqrt(2)
+		for _, p := range []tuple.Tuple{tuple.Point(0, 0, 0), tuple.Point(100, -20, 3)} {
+			samples := l.SamplesFrom(p)
+			Expect(samples).To(HaveLen(1))
+			Expect(samples[0].Direction).To(tuple.Equal(tuple.Vector(-r2/2, r2/2, 0)))
+			Expect(math.IsInf(samples[0].Distance, 1)).To(BeTrue())
+			Expect(samples[0].Intensity).To(color.Equal(color.New(1, 1, 1)))
+		}
+	})
+})
@@ -5,9 +5,28 @@ import (
 	"github.com/kieron-pivotal/rays/tuple"
 )
 
+// Light is anything that can illuminate a point. Each sample is shaded
+// and shadow tested on its own and the results averaged.
+type Light interface {
+	GetIntensity() color.Color
+	SamplesFrom(p tuple.Tuple) []Sample
+}
+
+// Sample is a single ray of light arriving at a point.
+type Sample struct {
+	// Direction is the unit vector from the point towards the light.
+	Direction tuple.Tuple
+	// Distance is how far a shadow ray has to travel to reach the light,
+	// which is infinite for a Directional light.
+	Distance    float64
+	Intensity   color.Color
+	Attenuation Attenuation
+}
+
 type Point struct {
-	Position  tuple.Tuple
-	Intensity color.Color
+	Position    tuple.Tuple
+	Intensity   color.Color
+	Attenuation Attenuation
 }
 
 func NewPoint(pos tuple.Tuple, intensity color.Color) Point {
@@ -16,3 +35,23 @@ func NewPoint(pos tuple.Tuple, intensity color.Color) Point {
 		Intensity: intensity,
 	}
 }
+
+func (l Point) GetIntensity() color.Color {
+	return l.Intensity
+}
+
+func (l Point) SamplesFrom(p tuple.Tuple) []Sample {
+	s := sampleTowards(p, l.Position, l.Intensity)
+	s.Attenuation = l.Attenuation
+	return []Sample{s}
+}
+
+func sampleTowards(from, to tuple.Tuple, intensity color.Color) Sample {
+	v := to.Subtract(from)
+	distance := v.Magnitude()
+	return Sample{
+		Direction: v.Divide(distance),
+		Distance:  distance,
+		Intensity: intensity,
+	}
+}
@@ -19,6 +19,15 @@ var _ = Describe("Lights", func() {
 			Expect(l.Position).To(tuple.Equal(pos))
 			Expect(l.Intensity).To(color.Equal(intensity))
 		})
+
+		It("is sampled once, from its position", func() {
+			l := light.NewPoint(tuple.Point(0, 3, 4), color.New(1, 0.5, 1))
+			samples := l.SamplesFrom(tuple.Point(0, 0, 0))
+			Expect(samples).To(HaveLen(1))
+			Expect(samples[0].Direction).To(tuple.Equal(tuple.Vector(0, 0.6, 0.8)))
+			Expect(samples[0].Distance).To(BeNumerically("~", 5))
+			Expect(samples[0].Intensity).To(color.Equal(color.New(1, 0.5, 1)))
+		})
 	})
 
 })
@@ -0,0 +1,56 @@
+package light
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/color"
+	"github.com/kieron-pivotal/rays/tuple"
+)
+
+// Spot is a point light that only shines within a cone around Direction.
+// Inside InnerAngle it is at full intensity, beyond OuterAngle it is dark,
+// and in between it fades smoothly. Both angles are measured from the axis.
+type Spot struct {
+	Position    tuple.Tuple
+	Direction   tuple.Tuple
+	InnerAngle  float64
+	OuterAngle  float64
+	Intensity   color.Color
+	Attenuation Attenuation
+}
+
+func NewSpot(pos, direction tuple.Tuple, innerAngle, outerAngle float64, intensity color.Color) Spot {
+	return Spot{
+		Position:   pos,
+		Direction:  direction.Normalize(),
+		InnerAngle: innerAngle,
+		OuterAngle: outerAngle,
+		Intensity:  intensity,
+	}
+}
+
+func (l Spot) GetIntensity() color.Color {
+	return l.Intensity
+}
+
+func (l Spot) SamplesFrom(p tuple.Tuple) []Sample {
+	s := sampleTowards(p, l.Position, l.Intensity)
+	s.Intensity = s.Intensity.Multiply(l.Falloff(p))
+	s.Attenuation = l.Attenuation
+	return []Sample{s}
+}
+
+// Falloff is the fraction of the light's intensity that reaches p.
+func (l Spot) Falloff(p tuple.Tuple) float64 {
+	cosAngle := p.Subtract(l.Position).Normalize().Dot(l.Direction)
+	cosInner := math.Cos(l.InnerAngle)
+	cosOuter := math.Cos(l.OuterAngle)
+	if cosAngle >= cosInner {
+		return 1
+	}
+	if cosAngle <= cosOuter {
+		return 0
+	}
+	t := (cosAngle - cosOuter) / (cosInner - cosOuter)
+	return t * t * (3 - 2*t)
+}
@@ -0,0 +1,69 @@
+package light_test
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/color"
+	"github.com/kieron-pivotal/rays/light"
+	"github.com/kieron-pivotal/rays/tuple"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Spot light", func() {
+	var l light.Spot
+
+	BeforeEach(func() {
+		l = light.NewSpot(
+			tuple.Point(0, 10, 0),
+			tuple.Vector(0, -2, 0),
+			math.Pi/6, math.Pi/4,
+			color.New(1, 1, 1),
+		)
+	})
+
+	It("has a position, normalised direction, cone and intensity", func() {
+		Expect(l.Position).To(tuple.Equal(tuple.Point(0, 10, 0)))
+		Expect(l.Direction).To(tuple.Equal(tuple.Vector(0, -1, 0)))
+		Expect(l.InnerAngle).To(BeNumerically("~", math.Pi/6))
+		Expect(l.OuterAngle).To(BeNumerically("~", math.Pi/4))
+		Expect(l.GetIntensity()).To(color.Equal(color.New(1, 1, 1)))
+	})
+
+	DescribeTable("falloff across the cone", func(angle, expected float64) {
+		p := tuple.Point(10*math.Tan(angle), 0, 0)
+		Expect(l.Falloff(p)).To(BeNumerically("~", expected, 1e-9))
+	},
+		Entry("on the axis", 0.0, 1.0),
+		Entry("at the inner edge", math.Pi/6, 1.0),
+		Entry("at the outer edge", math.Pi/4, 0.0),
+		Entry("outside the cone", math.Pi/3, 0.0),
+	)
+
+	It("doesn't shine backwards", func() {
+		Expect(l.Falloff(tuple.Point(0, 20, 0))).To(BeZero())
+	})
+
+	It("fades smoothly between the inner and outer cones", func() {
+		previous := 1.0
+		for angle := math.Pi / 6; angle <= math.Pi/4; angle += 0.01 {
+			f := l.Falloff(tuple.Point(10*math.Tan(angle), 0, 0))
+			Expect(f).To(BeNumerically("<=", previous))
+			previous = f
+		}
+		mid := (math.Cos(math.Pi/6) + math.Cos(math.Pi/4)) / 2
+		Expect(l.Falloff(tuple.Point(10*math.Tan(math.Acos(mid)), 0, 0))).To(BeNumerically("~", 0.5))
+	})
+
+	It("scales its single sample by the falloff", func() {
+		samples := l.SamplesFrom(tuple.Point(0, 0, 0))
+		Expect(samples).To(HaveLen(1))
+		Expect(samples[0].Direction).To(tuple.Equal(tuple.Vector(0, 1, 0)))
+		Expect(samples[0].Distance).To(BeNumerically("~", 10))
+		Expect(samples[0].Intensity).To(color.Equal(color.New(1, 1, 1)))
+
+		samples = l.SamplesFrom(tuple.Point(20, 0, 0))
+		Expect(samples[0].Intensity).To(color.Equal(color.New(0, 0, 0)))
+	})
+})
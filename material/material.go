@@ -5,7 +5,6 @@ import (
 
 	"github.com/kieron-pivotal/rays/color"
 	"github.com/kieron-pivotal/rays/light"
-	"github.com/kieron-pivotal/rays/matrix"
 	"github.com/kieron-pivotal/rays/pattern"
 	"github.com/kieron-pivotal/rays/tuple"
 )
@@ -35,49 +34,88 @@ func New() Material {
 	}
 }
 
-//go:generate counterfeiter . InvTransformGetter
+//go:generate counterfeiter . ObjectSpaceConverter
 
-type InvTransformGetter interface {
-	GetInverseTransform() matrix.Matrix
+type ObjectSpaceConverter interface {
+	WorldToObject(tuple.Tuple) tuple.Tuple
 }
 
+// Lighting shades pos as lit by l, where intensity is the fraction of the
+// light that isn't shadowed. The ambient term comes from l too, so it fades
+// with the light's attenuation.
 func (m Material) Lighting(
-	l light.Point,
-	invTransformGetter InvTransformGetter,
+	l light.Light,
+	obj ObjectSpaceConverter,
 	pos, eye, normal tuple.Tuple,
-	inShadow bool,
+	intensity float64,
 ) color.Color {
+	samples := l.SamplesFrom(pos)
+	ambientIntensity := l.GetIntensity().Multiply(meanAttenuation(samples))
+	ambient := m.AmbientLighting(ambientIntensity, obj, pos)
+	return ambient.Add(m.DirectLighting(samples, obj, pos, eye, normal).Multiply(intensity))
+}
 
-	black := color.New(0, 0, 0)
-	var ambient, diffuse, specular color.Color
+// AmbientLighting is the part of Lighting that doesn't depend on where the
+// light is, for the given intensity of ambient light.
+func (m Material) AmbientLighting(ambient color.Color, obj ObjectSpaceConverter, pos tuple.Tuple) color.Color {
+	return m.colorAt(obj, pos).ColorMultiply(ambient).Multiply(m.Ambient)
+}
 
-	c := m.Color
-	if m.pattern != nil {
-		c = m.pattern.PatternAtShape(invTransformGetter, pos)
+// DirectLighting is Lighting without the ambient term, for shading with
+// several lights and one ambient intensity. Diffuse and specular are
+// averaged over the light's samples, each dimmed by its attenuation, so a
+// shadowed sample should have its Intensity scaled down beforehand.
+func (m Material) DirectLighting(
+	samples []light.Sample,
+	obj ObjectSpaceConverter,
+	pos, eye, normal tuple.Tuple,
+) color.Color {
+	black := color.New(0, 0, 0)
+	sum := black
+	if len(samples) == 0 {
+		return sum
 	}
-	effectiveColor := c.ColorMultiply(l.Intensity)
-	ambient = effectiveColor.Multiply(m.Ambient)
 
-	lightV := l.Position.Subtract(pos).Normalize()
-	lightDotNormal := lightV.Dot(normal)
-	if inShadow || lightDotNormal < 0 {
-		diffuse = black
-		specular = black
-	} else {
-		diffuse = effectiveColor.Multiply(m.Diffuse).Multiply(lightDotNormal)
+	var c color.Color
+	coloured := false
+	for _, s := range samples {
+		lightDotNormal := s.Direction.Dot(normal)
+		if lightDotNormal < 0 || s.Intensity == black {
+			continue
+		}
+		// the pattern is only looked up once some light reaches pos
+		if !coloured {
+			c, coloured = m.colorAt(obj, pos), true
+		}
 
-		reflectV := lightV.Multiply(-1).Reflect(normal)
+		lightIntensity := s.Intensity.Multiply(s.Attenuation.Factor(s.Distance))
+		sum = sum.Add(c.ColorMultiply(lightIntensity).Multiply(m.Diffuse).Multiply(lightDotNormal))
+		reflectV := s.Direction.Multiply(-1).Reflect(normal)
 		reflectDotEye := reflectV.Dot(eye)
-
-		if reflectDotEye <= 0 {
-			specular = black
-		} else {
+		if reflectDotEye > 0 {
 			factor := math.Pow(reflectDotEye, m.Shininess)
-			specular = l.Intensity.Multiply(m.Specular).Multiply(factor)
+			sum = sum.Add(lightIntensity.Multiply(m.Specular).Multiply(factor))
 		}
 	}
+	return sum.Multiply(1 / float64(len(samples)))
+}
+
+func meanAttenuation(samples []light.Sample) float64 {
+	if len(samples) == 0 {
+		return 1
+	}
+	sum := 0.0
+	for _, s := range samples {
+		sum += s.Attenuation.Factor(s.Distance)
+	}
+	return sum / float64(len(samples))
+}
 
-	return ambient.Add(diffuse).Add(specular)
+func (m Material) colorAt(obj ObjectSpaceConverter, pos tuple.Tuple) color.Color {
+	if m.pattern != nil {
+		return m.pattern.PatternAtShape(obj, pos)
+	}
+	return m.Color
 }
 
 func (m *Material) SetPattern(p *pattern.Pattern) {
@@ -6,9 +6,9 @@ import (
 	"github.com/kieron-pivotal/rays/color"
 	"github.com/kieron-pivotal/rays/light"
 	"github.com/kieron-pivotal/rays/material"
+	"github.com/kieron-pivotal/rays/material/materialfakes"
 	"github.com/kieron-pivotal/rays/matrix"
 	"github.com/kieron-pivotal/rays/pattern"
-	"github.com/kieron-pivotal/rays/pattern/patternfakes"
 	"github.com/kieron-pivotal/rays/tuple"
 
 	. "github.com/onsi/ginkgo"
@@ -40,18 +40,18 @@ var _ = Describe("Material", func() {
 		})
 
 		DescribeTable("lighting",
-			func(eye, normal tuple.Tuple, l light.Point, inShadow bool, expected color.Color) {
+			func(eye, normal tuple.Tuple, l light.Point, intensity float64, expected color.Color) {
 				id := matrix.Identity(4, 4)
-				invGetter := new(patternfakes.FakeInvTransformGetter)
-				invGetter.GetInverseTransformReturns(id)
-				Expect(m.Lighting(l, invGetter, p, eye, normal, inShadow)).To(color.Equal(expected))
+				converter := new(materialfakes.FakeObjectSpaceConverter)
+				converter.WorldToObjectCalls(id.TupleMultiply)
+				Expect(m.Lighting(l, converter, p, eye, normal, intensity)).To(color.Equal(expected))
 			},
 
 			Entry("eye in front of light",
 				tuple.Vector(0, 0, -1),
 				tuple.Vector(0, 0, -1),
 				light.NewPoint(tuple.Point(0, 0, -10), color.New(1, 1, 1)),
-				false,
+				1.0,
 				color.New(1.9, 1.9, 1.9),
 			),
 
@@ -59,7 +59,7 @@ var _ = Describe("Material", func() {
 				tuple.Vector(0, r2/2, -r2/2),
 				tuple.Vector(0, 0, -1),
 				light.NewPoint(tuple.Point(0, 0, -10), color.New(1, 1, 1)),
-				false,
+				1.0,
 				color.New(1.0, 1.0, 1.0),
 			),
 
@@ -67,7 +67,7 @@ var _ = Describe("Material", func() {
 				tuple.Vector(0, 0, -1),
 				tuple.Vector(0, 0, -1),
 				light.NewPoint(tuple.Point(0, 10, -10), color.New(1, 1, 1)),
-				false,
+				1.0,
 				color.New(0.7364, 0.7364, 0.7364),
 			),
 
@@ -75,7 +75,7 @@ var _ = Describe("Material", func() {
 				tuple.Vector(0, -r2/2, -r2/2),
 				tuple.Vector(0, 0, -1),
 				light.NewPoint(tuple.Point(0, 10, -10), color.New(1, 1, 1)),
-				false,
+				1.0,
 				color.New(1.6364, 1.6364, 1.6364),
 			),
 
@@ -83,7 +83,7 @@ var _ = Describe("Material", func() {
 				tuple.Vector(0, 0, -1),
 				tuple.Vector(0, 0, -1),
 				light.NewPoint(tuple.Point(0, 0, 10), color.New(1, 1, 1)),
-				false,
+				1.0,
 				color.New(0.1, 0.1, 0.1),
 			),
 
@@ -91,13 +91,93 @@ var _ = Describe("Material", func() {
 				tuple.Vector(0, 0, -1),
 				tuple.Vector(0, 0, -1),
 				light.NewPoint(tuple.Point(0, 0, -10), color.New(1, 1, 1)),
-				true,
+				0.0,
 				color.New(0.1, 0.1, 0.1),
 			),
 		)
 
+		It("can give just the ambient term", func() {
+			id := matrix.Identity(4, 4)
+			converter := new(materialfakes.FakeObjectSpaceConverter)
+			converter.WorldToObjectCalls(id.TupleMultiply)
+			m.Color = color.New(1, 0.5, 0)
+			Expect(m.AmbientLighting(color.New(0.5, 1, 1), converter, p)).To(color.Equal(color.New(0.05, 0.05, 0)))
+		})
+
+		It("can light without the ambient term", func() {
+			id := matrix.Identity(4, 4)
+			converter := new(materialfakes.FakeObjectSpaceConverter)
+			converter.WorldToObjectCalls(id.TupleMultiply)
+			eye := tuple.Vector(0, 0, -1)
+			normal := tuple.Vector(0, 0, -1)
+			l := light.NewPoint(tuple.Point(0, 10, -10), color.New(1, 1, 1))
+
+			samples := l.SamplesFrom(p)
+			Expect(m.DirectLighting(samples, converter, p, eye, normal)).To(color.Equal(color.New(0.6364, 0.6364, 0.6364)))
+			samples[0].Intensity = color.New(0, 0, 0)
+			Expect(m.DirectLighting(samples, converter, p, eye, normal)).To(color.Equal(color.New(0, 0, 0)))
+		})
+	})
+
+	It("dims attenuated lights with distance", func() {
+		m := material.New()
+		id := matrix.Identity(4, 4)
+		converter := new(materialfakes.FakeObjectSpaceConverter)
+		converter.WorldToObjectCalls(id.TupleMultiply)
+		p := tuple.Point(0, 0, 0)
+		eye := tuple.Vector(0, 0, -1)
+		normal := tuple.Vector(0, 0, -1)
+
+		l := light.NewPoint(tuple.Point(0, 0, -10), color.New(1, 1, 1))
+		l.Attenuation = light.InverseSquare
+		Expect(m.Lighting(l, converter, p, eye, normal, 1)).To(color.Equal(color.New(0.019, 0.019, 0.019)))
+
+		l.Position = tuple.Point(0, 0, -1000)
+		Expect(m.Lighting(l, converter, p, eye, normal, 1)).To(color.Equal(color.New(0, 0, 0)))
+
+		l.Attenuation = light.Attenuation{}
+		Expect(m.Lighting(l, converter, p, eye, normal, 1)).To(color.Equal(color.New(1.9, 1.9, 1.9)))
+	})
+
+	It("dims the ambient term of an attenuated light too", func() {
+		m := material.New()
+		id := matrix.Identity(4, 4)
+		converter := new(materialfakes.FakeObjectSpaceConverter)
+		converter.WorldToObjectCalls(id.TupleMultiply)
+		p := tuple.Point(0, 0, 0)
+		eye := tuple.Vector(0, 0, -1)
+		normal := tuple.Vector(0, 0, -1)
+
+		l := light.NewPoint(tuple.Point(0, 0, -10), color.New(100, 100, 100))
+		l.Attenuation = light.InverseSquare
+		Expect(m.Lighting(l, converter, p, eye, normal, 1)).To(color.Equal(color.New(1.9, 1.9, 1.9)))
+		Expect(m.Lighting(l, converter, p, eye, normal, 0)).To(color.Equal(color.New(0.1, 0.1, 0.1)))
 	})
 
+	DescribeTable("lighting with an area light", func(pos tuple.Tuple, expected color.Color) {
+		l := light.NewArea(
+			tuple.Point(-0.5, -0.5, -5),
+			tuple.Vector(1, 0, 0), 2,
+			tuple.Vector(0, 1, 0), 2,
+			color.New(1, 1, 1),
+		)
+		l.Jitter = false
+		m := material.New()
+		m.Ambient = 0.1
+		m.Diffuse = 0.9
+		m.Specular = 0
+		id := matrix.Identity(4, 4)
+		converter := new(materialfakes.FakeObjectSpaceConverter)
+		converter.WorldToObjectCalls(id.TupleMultiply)
+
+		eye := tuple.Point(0, 0, -5).Subtract(pos).Normalize()
+		normal := pos.Subtract(tuple.Point(0, 0, 0))
+		Expect(m.Lighting(l, converter, pos, eye, normal, 1)).To(color.Equal(expected))
+	},
+		Entry("facing the light", tuple.Point(0, 0, -1), color.New(0.9965, 0.9965, 0.9965)),
+		Entry("at an angle", tuple.Point(0, 0.7071, -0.7071), color.New(0.62318, 0.62318, 0.62318)),
+	)
+
 	Context("with a pattern", func() {
 		It("gets the color right", func() {
 			m := material.New()
@@ -112,12 +192,12 @@ var _ = Describe("Material", func() {
 			normalv := tuple.Vector(0, 0, -1)
 			l := light.NewPoint(tuple.Point(0, 0, -10), color.New(1, 1, 1))
 			id := matrix.Identity(4, 4)
-			invGetter := new(patternfakes.FakeInvTransformGetter)
-			invGetter.GetInverseTransformReturns(id)
+			converter := new(materialfakes.FakeObjectSpaceConverter)
+			converter.WorldToObjectCalls(id.TupleMultiply)
 
-			c1 := m.Lighting(l, invGetter, tuple.Point(0.9, 0, 0), eyev, normalv, false)
+			c1 := m.Lighting(l, converter, tuple.Point(0.9, 0, 0), eyev, normalv, 1)
 			Expect(c1).To(color.Equal(color.New(1, 1, 1)))
-			c2 := m.Lighting(l, invGetter, tuple.Point(1.1, 0, 0), eyev, normalv, false)
+			c2 := m.Lighting(l, converter, tuple.Point(1.1, 0, 0), eyev, normalv, 1)
 			Expect(c2).To(color.Equal(color.New(0, 0, 0)))
 		})
 	})
@@ -0,0 +1,112 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package materialfakes
+
+import (
+	"sync"
+
+	"github.com/kieron-pivotal/rays/material"
+	"github.com/kieron-pivotal/rays/tuple"
+)
+
+type FakeObjectSpaceConverter struct {
+	WorldToObjectStub        func(tuple.Tuple) tuple.Tuple
+	worldToObjectMutex       sync.RWMutex
+	worldToObjectArgsForCall []struct {
+		arg1 tuple.Tuple
+	}
+	worldToObjectReturns struct {
+		result1 tuple.Tuple
+	}
+	worldToObjectReturnsOnCall map[int]struct {
+		result1 tuple.Tuple
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeObjectSpaceConverter) WorldToObject(arg1 tuple.Tuple) tuple.Tuple {
+	fake.worldToObjectMutex.Lock()
+	ret, specificReturn := fake.worldToObjectReturnsOnCall[len(fake.worldToObjectArgsForCall)]
+	fake.worldToObjectArgsForCall = append(fake.worldToObjectArgsForCall, struct {
+		arg1 tuple.Tuple
+	}{arg1})
+	stub := fake.WorldToObjectStub
+	fakeReturns := fake.worldToObjectReturns
+	fake.recordInvocation("WorldToObject", []interface{}{arg1})
+	fake.worldToObjectMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeObjectSpaceConverter) WorldToObjectCallCount() int {
+	fake.worldToObjectMutex.RLock()
+	defer fake.worldToObjectMutex.RUnlock()
+	return len(fake.worldToObjectArgsForCall)
+}
+
+func (fake *FakeObjectSpaceConverter) WorldToObjectCalls(stub func(tuple.Tuple) tuple.Tuple) {
+	fake.worldToObjectMutex.Lock()
+	defer fake.worldToObjectMutex.Unlock()
+	fake.WorldToObjectStub = stub
+}
+
+func (fake *FakeObjectSpaceConverter) WorldToObjectArgsForCall(i int) tuple.Tuple {
+	fake.worldToObjectMutex.RLock()
+	defer fake.worldToObjectMutex.RUnlock()
+	argsForCall := fake.worldToObjectArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeObjectSpaceConverter) WorldToObjectReturns(result1 tuple.Tuple) {
+	fake.worldToObjectMutex.Lock()
+	defer fake.worldToObjectMutex.Unlock()
+	fake.WorldToObjectStub = nil
+	fake.worldToObjectReturns = struct {
+		result1 tuple.Tuple
+	}{result1}
+}
+
+func (fake *FakeObjectSpaceConverter) WorldToObjectReturnsOnCall(i int, result1 tuple.Tuple) {
+	fake.worldToObjectMutex.Lock()
+	defer fake.worldToObjectMutex.Unlock()
+	fake.WorldToObjectStub = nil
+	if fake.worldToObjectReturnsOnCall == nil {
+		fake.worldToObjectReturnsOnCall = make(map[int]struct {
+			result1 tuple.Tuple
+		})
+	}
+	fake.worldToObjectReturnsOnCall[i] = struct {
+		result1 tuple.Tuple
+	}{result1}
+}
+
+func (fake *FakeObjectSpaceConverter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.worldToObjectMutex.RLock()
+	defer fake.worldToObjectMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeObjectSpaceConverter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ material.ObjectSpaceConverter = new(FakeObjectSpaceConverter)
@@ -18,10 +18,10 @@ type ActualPattern interface {
 	PatternAt(p tuple.Tuple) color.Color
 }
 
-//go:generate counterfeiter . InvTransformGetter
+//go:generate counterfeiter . ObjectSpaceConverter
 
-type InvTransformGetter interface {
-	GetInverseTransform() matrix.Matrix
+type ObjectSpaceConverter interface {
+	WorldToObject(tuple.Tuple) tuple.Tuple
 }
 
 func New(actualPattern ActualPattern) Pattern {
@@ -41,8 +41,8 @@ func (p *Pattern) SetTransform(t matrix.Matrix) {
 	p.inverseTransform = t.Inverse()
 }
 
-func (p Pattern) PatternAtShape(obj InvTransformGetter, wp tuple.Tuple) color.Color {
-	op := obj.GetInverseTransform().TupleMultiply(wp)
+func (p Pattern) PatternAtShape(obj ObjectSpaceConverter, wp tuple.Tuple) color.Color {
+	op := obj.WorldToObject(wp)
 	pp := p.inverseTransform.TupleMultiply(op)
 	return p.actualPattern.PatternAt(pp)
 }
@@ -20,13 +20,13 @@ var _ = Describe("Pattern", func() {
 	Context("patterns", func() {
 		var (
 			fakePattern   *patternfakes.FakeActualPattern
-			fakeInvGetter *patternfakes.FakeInvTransformGetter
+			fakeConverter *patternfakes.FakeObjectSpaceConverter
 			p             pattern.Pattern
 		)
 
 		BeforeEach(func() {
 			fakePattern = new(patternfakes.FakeActualPattern)
-			fakeInvGetter = new(patternfakes.FakeInvTransformGetter)
+			fakeConverter = new(patternfakes.FakeObjectSpaceConverter)
 			p = pattern.New(fakePattern)
 		})
 
@@ -42,8 +42,8 @@ var _ = Describe("Pattern", func() {
 
 		It("transforms the point when the object has a transformation", func() {
 			t := matrix.Scaling(2, 2, 2).Inverse()
-			fakeInvGetter.GetInverseTransformReturns(t)
-			p.PatternAtShape(fakeInvGetter, tuple.Point(2, 3, 4))
+			fakeConverter.WorldToObjectCalls(t.TupleMultiply)
+			p.PatternAtShape(fakeConverter, tuple.Point(2, 3, 4))
 			Expect(fakePattern.PatternAtCallCount()).To(Equal(1))
 			op := fakePattern.PatternAtArgsForCall(0)
 			Expect(op).To(tuple.Equal(tuple.Point(1, 1.5, 2)))
@@ -52,8 +52,8 @@ var _ = Describe("Pattern", func() {
 		It("transforms the point when the pattern has a transformation", func() {
 			t := matrix.Scaling(2, 2, 2)
 			p.SetTransform(t)
-			fakeInvGetter.GetInverseTransformReturns(matrix.Identity(4, 4))
-			p.PatternAtShape(fakeInvGetter, tuple.Point(2, 3, 4))
+			fakeConverter.WorldToObjectCalls(matrix.Identity(4, 4).TupleMultiply)
+			p.PatternAtShape(fakeConverter, tuple.Point(2, 3, 4))
 			Expect(fakePattern.PatternAtCallCount()).To(Equal(1))
 			op := fakePattern.PatternAtArgsForCall(0)
 			Expect(op).To(tuple.Equal(tuple.Point(1, 1.5, 2)))
@@ -61,10 +61,10 @@ var _ = Describe("Pattern", func() {
 
 		It("transforms the point when both obj and pattern have transforms", func() {
 			t := matrix.Scaling(2, 2, 2).Inverse()
-			fakeInvGetter.GetInverseTransformReturns(t)
+			fakeConverter.WorldToObjectCalls(t.TupleMultiply)
 			s := matrix.Translation(0.5, 1, 1.5)
 			p.SetTransform(s)
-			p.PatternAtShape(fakeInvGetter, tuple.Point(3, 4, 5))
+			p.PatternAtShape(fakeConverter, tuple.Point(3, 4, 5))
 			Expect(fakePattern.PatternAtCallCount()).To(Equal(1))
 			op := fakePattern.PatternAtArgsForCall(0)
 			Expect(op).To(tuple.Equal(tuple.Point(1, 1, 1)))
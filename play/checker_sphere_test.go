@@ -1,7 +1,6 @@
 package play_test
 
 import (
-	"fmt"
 	"log"
 	"math"
 	"os"
@@ -39,7 +38,7 @@ var _ = Describe("CheckerSphere", func() {
 		w.AddObject(s)
 
 		l := light.NewPoint(tuple.Point(10, 10, 10), color.New(1, 1, 1))
-		w.LightSource = &l
+		w.AddLight(l)
 
 		camera := camera.New(300, 200, math.Pi/4)
 		camera.SetTransform(  matrix.ViewTransformation(
@@ -50,14 +49,15 @@ var _ = Describe("CheckerSphere", func() {
 
 		canvas := camera.Render(w)
 
-		out, err := os.Create("checker_sphere.ppm")
+		out, err := os.Create("checker_sphere.png")
 		if err != nil {
 			log.Fatal(err)
 		}
 		defer out.Close()
 
-		img := canvas.ToPPM()
-		fmt.Fprint(out, img)
+		if err := canvas.WritePNG(out); err != nil {
+			log.Fatal(err)
+		}
 	})
 
 })
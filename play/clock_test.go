@@ -31,10 +31,10 @@ var _ = Describe("Clock", func() {
 			c.SetPixel(int(math.Round(p.X)), int(math.Round(p.Y)), col)
 		}
 
-		file, err := os.Create("clock.ppm")
+		file, err := os.Create("clock.png")
 		Expect(err).NotTo(HaveOccurred())
 
-		file.WriteString(c.ToPPM())
+		Expect(c.WritePNG(file)).To(Succeed())
 		file.Close()
 	})
 
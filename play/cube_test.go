@@ -1,10 +1,10 @@
 package play_test
 
 import (
-	"fmt"
 	"log"
 	"math"
 	"os"
+	"runtime"
 
 	"github.com/kieron-pivotal/rays/camera"
 	"github.com/kieron-pivotal/rays/color"
@@ -34,7 +34,7 @@ var _ = Describe("Cube", func() {
 		w.AddObject(f)
 
 		l := light.NewPoint(tuple.Point(-50, -100, 100), color.New(1, 1, 1))
-		w.LightSource = &l
+		w.AddLight(l)
 
 		tMat := material.New()
 		tMat.Color = color.New(165.0/255.0, 42.0/255.0, 42.0/255.0)
@@ -79,16 +79,19 @@ var _ = Describe("Cube", func() {
 			tuple.Vector(0, 0, 1),
 		))
 
+		cam.Workers = runtime.NumCPU()
+
 		canvas := cam.Render(w)
 
-		out, err := os.Create("table.ppm")
+		out, err := os.Create("table.png")
 		if err != nil {
 			log.Fatal(err)
 		}
 		defer out.Close()
 
-		img := canvas.ToPPM()
-		fmt.Fprint(out, img)
+		if err := canvas.WritePNG(out); err != nil {
+			log.Fatal(err)
+		}
 
 	})
 
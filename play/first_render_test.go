@@ -40,10 +40,10 @@ var _ = Describe("FirstRender", func() {
 				}
 			}
 		}
-		file, err := os.Create("first_render.ppm")
+		file, err := os.Create("first_render.png")
 		Expect(err).NotTo(HaveOccurred())
 
-		file.WriteString(canv.ToPPM())
+		Expect(canv.WritePNG(file)).To(Succeed())
 		file.Close()
 	})
 
@@ -74,15 +74,15 @@ var _ = Describe("FirstRender", func() {
 					hitPoint := ray.Position(hit.T)
 					normal := s.NormalAt(hitPoint)
 					eye := ray.Direction.Multiply(-1)
-					col := hit.Object.Material().Lighting(l, hit.Object, hitPoint, eye, normal, false)
+					col := hit.Object.Material().Lighting(l, hit.Object, hitPoint, eye, normal, 1)
 					canv.SetPixel(r, c, col)
 				}
 			}
 		}
-		file, err := os.Create("second_render.ppm")
+		file, err := os.Create("second_render.png")
 		Expect(err).NotTo(HaveOccurred())
 
-		file.WriteString(canv.ToPPM())
+		Expect(canv.WritePNG(file)).To(Succeed())
 		file.Close()
 	})
 
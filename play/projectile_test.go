@@ -41,7 +41,7 @@ var _ = Describe("Projectile", func() {
 		}
 	})
 
-	It("can plot the project to PPM", func() {
+	It("can plot the project to PNG", func() {
 		env := play.NewEnv(gravity, wind)
 		Expect(env).ToNot(BeNil())
 
@@ -75,10 +75,10 @@ var _ = Describe("Projectile", func() {
 			canvas.SetPixel(x, height-y-1, red)
 		}
 
-		file, err := os.Create("projectile.ppm")
+		file, err := os.Create("projectile.png")
 		Expect(err).NotTo(HaveOccurred())
 
-		file.WriteString(canvas.ToPPM())
+		Expect(canvas.WritePNG(file)).To(Succeed())
 		file.Close()
 	})
 })
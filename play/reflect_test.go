@@ -1,7 +1,6 @@
 package play_test
 
 import (
-	"fmt"
 	"log"
 	"math"
 	"os"
@@ -33,7 +32,7 @@ var _ = Describe("Reflect", func() {
 
 		w := world.New()
 		l := light.NewPoint(tuple.Point(0, 10, 10), color.New(1, 1, 1))
-		w.LightSource = &l
+		w.AddLight(l)
 
 		plane := shape.NewPlane()
 		mp := material.New()
@@ -73,14 +72,15 @@ var _ = Describe("Reflect", func() {
 
 		canvas := camera.Render(w)
 
-		out, err := os.Create("reflect.ppm")
+		out, err := os.Create("reflect.png")
 		if err != nil {
 			log.Fatal(err)
 		}
 		defer out.Close()
 
-		img := canvas.ToPPM()
-		fmt.Fprint(out, img)
+		if err := canvas.WritePNG(out); err != nil {
+			log.Fatal(err)
+		}
 
 	})
 
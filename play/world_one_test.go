@@ -1,7 +1,6 @@
 package play_test
 
 import (
-	"fmt"
 	"log"
 	"math"
 	"os"
@@ -86,7 +85,7 @@ var _ = Describe("WorldOne", func() {
 		w.AddObject(right)
 
 		lightSource := light.NewPoint(tuple.Point(-10, 10, -10), color.New(1, 1, 1))
-		w.LightSource = &lightSource
+		w.AddLight(lightSource)
 
 		camera := camera.New(300, 180, math.Pi/3)
 		camera.SetTransform ( matrix.ViewTransformation(
@@ -97,14 +96,15 @@ var _ = Describe("WorldOne", func() {
 
 		canvas := camera.Render(w)
 
-		out, err := os.Create("world_one.ppm")
+		out, err := os.Create("world_one.png")
 		if err != nil {
 			log.Fatal(err)
 		}
 		defer out.Close()
 
-		img := canvas.ToPPM()
-		fmt.Fprint(out, img)
+		if err := canvas.WritePNG(out); err != nil {
+			log.Fatal(err)
+		}
 	})
 
 	It("can draw the scene with a plane", func() {
@@ -166,7 +166,7 @@ var _ = Describe("WorldOne", func() {
 		w.AddObject(right)
 
 		lightSource := light.NewPoint(tuple.Point(0, 10, 0), color.New(1, 1, 1))
-		w.LightSource = &lightSource
+		w.AddLight(lightSource)
 
 		camera := camera.New(150, 90, math.Pi/2)
 		camera.SetTransform ( matrix.ViewTransformation(
@@ -177,13 +177,14 @@ var _ = Describe("WorldOne", func() {
 
 		canvas := camera.Render(w)
 
-		out, err := os.Create("world_two.ppm")
+		out, err := os.Create("world_two.png")
 		if err != nil {
 			log.Fatal(err)
 		}
 		defer out.Close()
 
-		img := canvas.ToPPM()
-		fmt.Fprint(out, img)
+		if err := canvas.WritePNG(out); err != nil {
+			log.Fatal(err)
+		}
 	})
 })
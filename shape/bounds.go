@@ -0,0 +1,129 @@
+package shape
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/matrix"
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/tuple"
+)
+
+type Bounds struct {
+	Min tuple.Tuple
+	Max tuple.Tuple
+}
+
+func NewBounds(min, max tuple.Tuple) Bounds {
+	return Bounds{Min: min, Max: max}
+}
+
+func EmptyBounds() Bounds {
+	inf := math.Inf(1)
+	return Bounds{
+		Min: tuple.Point(inf, inf, inf),
+		Max: tuple.Point(-inf, -inf, -inf),
+	}
+}
+
+func InfiniteBounds() Bounds {
+	inf := math.Inf(1)
+	return Bounds{
+		Min: tuple.Point(-inf, -inf, -inf),
+		Max: tuple.Point(inf, inf, inf),
+	}
+}
+
+func (b Bounds) AddPoint(p tuple.Tuple) Bounds {
+	return Bounds{
+		Min: tuple.Point(math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y), math.Min(b.Min.Z, p.Z)),
+		Max: tuple.Point(math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y), math.Max(b.Max.Z, p.Z)),
+	}
+}
+
+func (b Bounds) Merge(c Bounds) Bounds {
+	return b.AddPoint(c.Min).AddPoint(c.Max)
+}
+
+func (b Bounds) IsEmpty() bool {
+	return b.Min.X > b.Max.X || b.Min.Y > b.Max.Y || b.Min.Z > b.Max.Z
+}
+
+func (b Bounds) IsInfinite() bool {
+	for _, v := range []float64{b.Min.X, b.Min.Y, b.Min.Z, b.Max.X, b.Max.Y, b.Max.Z} {
+		if math.IsInf(v, 0) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b Bounds) Centre() tuple.Tuple {
+	return tuple.Point(
+		(b.Min.X+b.Max.X)/2,
+		(b.Min.Y+b.Max.Y)/2,
+		(b.Min.Z+b.Max.Z)/2,
+	)
+}
+
+// Transform uses the per-axis method rather than transforming the eight
+// corners so that infinite extents don't turn into NaNs.
+func (b Bounds) Transform(m matrix.Matrix) Bounds {
+	if b.IsEmpty() {
+		return b
+	}
+
+	min := [3]float64{b.Min.X, b.Min.Y, b.Min.Z}
+	max := [3]float64{b.Max.X, b.Max.Y, b.Max.Z}
+	var outMin, outMax [3]float64
+
+	for i := 0; i < 3; i++ {
+		outMin[i] = m.Get(i, 3)
+		outMax[i] = m.Get(i, 3)
+		for j := 0; j < 3; j++ {
+			f := m.Get(i, j)
+			if f == 0 {
+				continue
+			}
+			a, c := f*min[j], f*max[j]
+			outMin[i] += math.Min(a, c)
+			outMax[i] += math.Max(a, c)
+		}
+	}
+
+	return Bounds{
+		Min: tuple.Point(outMin[0], outMin[1], outMin[2]),
+		Max: tuple.Point(outMax[0], outMax[1], outMax[2]),
+	}
+}
+
+func (b Bounds) Intersects(r ray.Ray) bool {
+	if b.IsEmpty() {
+		return false
+	}
+
+	tmin, tmax := math.Inf(-1), math.Inf(1)
+	origin := [3]float64{r.Origin.X, r.Origin.Y, r.Origin.Z}
+	direction := [3]float64{r.Direction.X, r.Direction.Y, r.Direction.Z}
+	min := [3]float64{b.Min.X, b.Min.Y, b.Min.Z}
+	max := [3]float64{b.Max.X, b.Max.Y, b.Max.Z}
+
+	for i := 0; i < 3; i++ {
+		if math.Abs(direction[i]) < tuple.EPSILON {
+			if origin[i] < min[i]-tuple.EPSILON || origin[i] > max[i]+tuple.EPSILON {
+				return false
+			}
+			continue
+		}
+		t0 := (min[i] - origin[i]) / direction[i]
+		t1 := (max[i] - origin[i]) / direction[i]
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		tmin = math.Max(tmin, t0)
+		tmax = math.Min(tmax, t1)
+		if tmin > tmax+tuple.EPSILON {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+package shape_test
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/matrix"
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/shape"
+	"github.com/kieron-pivotal/rays/tuple"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bounds", func() {
+
+	inf := math.Inf(1)
+
+	It("starts empty and grows as points are added", func() {
+		b := shape.EmptyBounds()
+		Expect(b.IsEmpty()).To(BeTrue())
+		b = b.AddPoint(tuple.Point(-5, 2, 0)).AddPoint(tuple.Point(7, 0, -3))
+		Expect(b.IsEmpty()).To(BeFalse())
+		Expect(b.Min).To(tuple.Equal(tuple.Point(-5, 0, -3)))
+		Expect(b.Max).To(tuple.Equal(tuple.Point(7, 2, 0)))
+	})
+
+	It("can be merged with other bounds", func() {
+		b1 := shape.NewBounds(tuple.Point(-5, -2, 0), tuple.Point(7, 4, 4))
+		b2 := shape.NewBounds(tuple.Point(8, -7, -2), tuple.Point(14, 2, 8))
+		b := b1.Merge(b2)
+		Expect(b.Min).To(tuple.Equal(tuple.Point(-5, -7, -2)))
+		Expect(b.Max).To(tuple.Equal(tuple.Point(14, 4, 8)))
+	})
+
+	It("can be transformed", func() {
+		b := shape.NewBounds(tuple.Point(-1, -1, -1), tuple.Point(1, 1, 1))
+		t := matrix.RotationY(math.Pi / 4).RotateX(math.Pi / 4)
+		b2 := b.Transform(t)
+		Expect(b2.Min).To(tuple.Equal(tuple.Point(-1.41421, -1.70710, -1.70710)))
+		Expect(b2.Max).To(tuple.Equal(tuple.Point(1.41421, 1.70710, 1.70710)))
+	})
+
+	It("stays finite-safe when transforming infinite bounds", func() {
+		b := shape.Plane{}.Bounds().Transform(matrix.Translation(0, 2, 0))
+		Expect(b.Min.X).To(Equal(-inf))
+		Expect(b.Min.Y).To(BeNumerically("~", 2))
+		Expect(b.Max.Y).To(BeNumerically("~", 2))
+		Expect(b.Max.Z).To(Equal(inf))
+		Expect(b.IsInfinite()).To(BeTrue())
+	})
+
+	DescribeTable("bounds of primitive shapes", func(b shape.Bounds, min, max tuple.Tuple) {
+		Expect(b.Min).To(Equal(min))
+		Expect(b.Max).To(Equal(max))
+	},
+
+		Entry("sphere", shape.Sphere{}.Bounds(), tuple.Point(-1, -1, -1), tuple.Point(1, 1, 1)),
+		Entry("cube", shape.Cube{}.Bounds(), tuple.Point(-1, -1, -1), tuple.Point(1, 1, 1)),
+		Entry("plane", shape.Plane{}.Bounds(), tuple.Point(-inf, 0, -inf), tuple.Point(inf, 0, inf)),
+		Entry("unbounded cylinder", shape.Cylinder{Minimum: -inf, Maximum: inf}.Bounds(),
+			tuple.Point(-1, -inf, -1), tuple.Point(1, inf, 1)),
+		Entry("bounded cylinder", shape.Cylinder{Minimum: -5, Maximum: 3}.Bounds(),
+			tuple.Point(-1, -5, -1), tuple.Point(1, 3, 1)),
+		Entry("bounded cone", shape.Cone{Minimum: -5, Maximum: 3}.Bounds(),
+			tuple.Point(-5, -5, -5), tuple.Point(5, 3, 5)),
+		Entry("triangle",
+			shape.NewTriangle(tuple.Point(-3, 7, 2), tuple.Point(6, 2, -4), tuple.Point(2, -1, -1)).LocalObject().(*shape.Triangle).Bounds(),
+			tuple.Point(-3, -1, -4), tuple.Point(6, 7, 2)),
+	)
+
+	It("bounds a group by its transformed children", func() {
+		s := shape.NewSphere()
+		s.SetTransform(matrix.Scaling(2, 2, 2).Translate(2, 5, -3))
+		c := shape.NewTruncatedCylinder(-2, 2, false)
+		c.SetTransform(matrix.Scaling(0.5, 1, 0.5).Translate(-4, -1, 4))
+		g := shape.NewGroup()
+		g.AddChild(s)
+		g.AddChild(c)
+		b := g.LocalObject().(*shape.Group).Bounds()
+		Expect(b.Min).To(tuple.Equal(tuple.Point(-4.5, -3, -5)))
+		Expect(b.Max).To(tuple.Equal(tuple.Point(4, 7, 4.5)))
+	})
+
+	It("bounds a CSG by both of its children", func() {
+		left := shape.NewSphere()
+		right := shape.NewSphere()
+		right.SetTransform(matrix.Translation(2, 3, 4))
+		c := shape.NewCSG(shape.CSGDifference, left, right)
+		b := c.LocalObject().(*shape.CSG).Bounds()
+		Expect(b.Min).To(tuple.Equal(tuple.Point(-1, -1, -1)))
+		Expect(b.Max).To(tuple.Equal(tuple.Point(3, 4, 5)))
+	})
+
+	DescribeTable("intersecting a ray with bounds", func(origin, direction tuple.Tuple, result bool) {
+		b := shape.NewBounds(tuple.Point(5, -2, 0), tuple.Point(11, 4, 7))
+		r := ray.New(origin, direction.Normalize())
+		Expect(b.Intersects(r)).To(Equal(result))
+	},
+
+		Entry("+x", tuple.Point(15, 1, 2), tuple.Vector(-1, 0, 0), true),
+		Entry("-x", tuple.Point(-5, -1, 4), tuple.Vector(1, 0, 0), true),
+		Entry("+y", tuple.Point(7, 6, 5), tuple.Vector(0, -1, 0), true),
+		Entry("-y", tuple.Point(9, -5, 6), tuple.Vector(0, 1, 0), true),
+		Entry("+z", tuple.Point(8, 2, 12), tuple.Vector(0, 0, -1), true),
+		Entry("-z", tuple.Point(6, 0, -5), tuple.Vector(0, 0, 1), true),
+		Entry("inside", tuple.Point(8, 1, 3.5), tuple.Vector(0, 0, 1), true),
+		Entry("miss 1", tuple.Point(9, -1, -8), tuple.Vector(2, 4, 6), false),
+		Entry("miss 2", tuple.Point(8, 3, -4), tuple.Vector(6, 2, 4), false),
+		Entry("miss 3", tuple.Point(9, -1, -2), tuple.Vector(4, 6, 2), false),
+		Entry("miss 4", tuple.Point(4, 0, 9), tuple.Vector(0, 0, -1), false),
+		Entry("miss 5", tuple.Point(8, 6, -1), tuple.Vector(0, -1, 0), false),
+		Entry("miss 6", tuple.Point(12, 5, 4), tuple.Vector(-1, 0, 0), false),
+	)
+})
@@ -0,0 +1,134 @@
+package shape
+
+import (
+	"sort"
+
+	"github.com/kieron-pivotal/rays/matrix"
+	"github.com/kieron-pivotal/rays/ray"
+)
+
+const bvhLeafSize = 4
+
+// BVH is a bounding volume hierarchy over a snapshot of some objects. The
+// contents of groups are pulled up into it, so it goes stale if any of the
+// objects are changed, moved or added to afterwards.
+type BVH struct {
+	root      *bvhNode
+	unbounded []bvhItem
+}
+
+type bvhNode struct {
+	bounds Bounds
+	items  []bvhItem
+	left   *bvhNode
+	right  *bvhNode
+}
+
+type bvhItem struct {
+	object *Object
+	bounds Bounds
+	// fromTop takes rays into the object's parent space, for objects
+	// inside groups
+	fromTop *matrix.Matrix
+}
+
+func NewBVH(objects []*Object) *BVH {
+	bvh := &BVH{}
+	items := []bvhItem{}
+	var add func(o *Object, toTop, fromTop *matrix.Matrix)
+	add = func(o *Object, toTop, fromTop *matrix.Matrix) {
+		if g, ok := o.localObject.(*Group); ok {
+			childToTop := o.transform
+			childFromTop := o.inverseTransform
+			if toTop != nil {
+				childToTop = toTop.Multiply(o.transform)
+				childFromTop = o.inverseTransform.Multiply(*fromTop)
+			}
+			for _, child := range g.children {
+				add(child, &childToTop, &childFromTop)
+			}
+			return
+		}
+
+		b := o.ParentSpaceBounds()
+		if toTop != nil {
+			b = b.Transform(*toTop)
+		}
+		item := bvhItem{object: o, bounds: b, fromTop: fromTop}
+		switch {
+		case b.IsEmpty():
+		case b.IsInfinite():
+			bvh.unbounded = append(bvh.unbounded, item)
+		default:
+			items = append(items, item)
+		}
+	}
+	for _, o := range objects {
+		add(o, nil, nil)
+	}
+	if len(items) > 0 {
+		bvh.root = buildBVHNode(items)
+	}
+	return bvh
+}
+
+func buildBVHNode(items []bvhItem) *bvhNode {
+	n := &bvhNode{bounds: EmptyBounds()}
+	centres := EmptyBounds()
+	for _, item := range items {
+		n.bounds = n.bounds.Merge(item.bounds)
+		centres = centres.AddPoint(item.bounds.Centre())
+	}
+
+	if len(items) <= bvhLeafSize {
+		n.items = items
+		return n
+	}
+
+	extent := centres.Max.Subtract(centres.Min)
+	axis := func(b Bounds) float64 { return b.Centre().X }
+	if extent.Y > extent.X && extent.Y >= extent.Z {
+		axis = func(b Bounds) float64 { return b.Centre().Y }
+	} else if extent.Z > extent.X && extent.Z > extent.Y {
+		axis = func(b Bounds) float64 { return b.Centre().Z }
+	}
+
+	sort.SliceStable(items, func(a, b int) bool {
+		return axis(items[a].bounds) < axis(items[b].bounds)
+	})
+	mid := len(items) / 2
+	n.left = buildBVHNode(items[:mid])
+	n.right = buildBVHNode(items[mid:])
+	return n
+}
+
+func (b *BVH) Intersect(r ray.Ray) *Intersections {
+	xs := NewIntersections()
+	for _, item := range b.unbounded {
+		xs.Merge(item.intersect(r))
+	}
+	if b.root != nil {
+		b.root.intersect(r, xs)
+	}
+	return xs
+}
+
+func (n *bvhNode) intersect(r ray.Ray, xs *Intersections) {
+	if !n.bounds.Intersects(r) {
+		return
+	}
+	for _, item := range n.items {
+		xs.Merge(item.intersect(r))
+	}
+	if n.left != nil {
+		n.left.intersect(r, xs)
+		n.right.intersect(r, xs)
+	}
+}
+
+func (i bvhItem) intersect(r ray.Ray) *Intersections {
+	if i.fromTop != nil {
+		r = r.Transform(*i.fromTop)
+	}
+	return i.object.Intersect(r)
+}
@@ -0,0 +1,93 @@
+package shape_test
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/matrix"
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/shape"
+	"github.com/kieron-pivotal/rays/tuple"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BVH", func() {
+
+	var (
+		objects []*shape.Object
+	)
+
+	BeforeEach(func() {
+		objects = nil
+		for x := -5; x <= 5; x++ {
+			for y := -5; y <= 5; y++ {
+				s := shape.NewSphere()
+				s.SetTransform(matrix.Scaling(0.4, 0.4, 0.4).Translate(float64(x), float64(y), 0))
+				objects = append(objects, s)
+			}
+		}
+	})
+
+	It("finds the same intersections as testing every object", func() {
+		bvh := shape.NewBVH(objects)
+		for _, r := range []ray.Ray{
+			ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1)),
+			ray.New(tuple.Point(3.1, -2, -5), tuple.Vector(0, 0, 1)),
+			ray.New(tuple.Point(-10, 0.1, 0), tuple.Vector(1, 0, 0)),
+			ray.New(tuple.Point(-6, -6, -1), tuple.Vector(1, 1, 0.2).Normalize()),
+			ray.New(tuple.Point(0.5, 0.5, -5), tuple.Vector(0, 0, 1)),
+		} {
+			expected := shape.NewIntersections()
+			for _, o := range objects {
+				expected.Merge(o.Intersect(r))
+			}
+			xs := bvh.Intersect(r)
+			Expect(xs.Count()).To(Equal(expected.Count()))
+			for i := 0; i < xs.Count(); i++ {
+				Expect(xs.Get(i).T).To(BeNumerically("~", expected.Get(i).T))
+				Expect(xs.Get(i).Object).To(Equal(expected.Get(i).Object))
+			}
+		}
+	})
+
+	It("always tests objects with infinite bounds", func() {
+		p := shape.NewPlane()
+		p.SetTransform(matrix.Translation(0, -100, 0))
+		bvh := shape.NewBVH(append(objects, p))
+		r := ray.New(tuple.Point(50, 0, 0), tuple.Vector(0, -1, 0))
+		xs := bvh.Intersect(r)
+		Expect(xs.Count()).To(Equal(1))
+		Expect(xs.Get(0).Object).To(Equal(p))
+	})
+
+	It("reaches into nested, transformed groups", func() {
+		outer := shape.NewGroup()
+		outer.SetTransform(matrix.Translation(0, 0, 10))
+		inner := shape.NewGroup()
+		inner.SetTransform(matrix.Scaling(2, 2, 2))
+		outer.AddChild(inner)
+		for _, o := range objects {
+			inner.AddChild(o)
+		}
+		plane := shape.NewPlane()
+		plane.SetTransform(matrix.RotationX(math.Pi / 2))
+		outer.AddChild(plane)
+
+		bvh := shape.NewBVH([]*shape.Object{outer})
+		for _, r := range []ray.Ray{
+			ray.New(tuple.Point(4, 6, 0), tuple.Vector(0, 0, 1)),
+			ray.New(tuple.Point(1, 1, 0), tuple.Vector(0, 0, 1)),
+			ray.New(tuple.Point(-20, 0.1, 10), tuple.Vector(1, 0, 0.01)),
+		} {
+			expected := outer.Intersect(r)
+			xs := bvh.Intersect(r)
+			Expect(xs.Count()).To(Equal(expected.Count()))
+			for i := 0; i < xs.Count(); i++ {
+				Expect(xs.Get(i).T).To(BeNumerically("~", expected.Get(i).T))
+				Expect(xs.Get(i).Object).To(Equal(expected.Get(i).Object))
+			}
+		}
+		xs := bvh.Intersect(ray.New(tuple.Point(4, 6, 0), tuple.Vector(0, 0, 1)))
+		Expect(xs.Get(0).T).To(BeNumerically("~", 9.2))
+	})
+})
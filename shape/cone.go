@@ -0,0 +1,109 @@
+package shape
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/tuple"
+)
+
+type Cone struct {
+	Minimum float64
+	Maximum float64
+	Closed  bool
+}
+
+func NewCone() *Object {
+	return New(Cone{
+		Minimum: math.Inf(-1),
+		Maximum: math.Inf(1),
+	})
+}
+
+func NewTruncatedCone(min, max float64, closed bool) *Object {
+	return New(Cone{
+		Minimum: min,
+		Maximum: max,
+		Closed:  closed,
+	})
+}
+
+func (c Cone) Name() string {
+	return "Cone"
+}
+
+func (c Cone) LocalIntersect(r ray.Ray) []float64 {
+	xs := []float64{}
+
+	o, d := r.Origin, r.Direction
+	a := d.X*d.X - d.Y*d.Y + d.Z*d.Z
+	b := 2*o.X*d.X - 2*o.Y*d.Y + 2*o.Z*d.Z
+	cc := o.X*o.X - o.Y*o.Y + o.Z*o.Z
+
+	ts := []float64{}
+	if math.Abs(a) < tuple.EPSILON {
+		if math.Abs(b) >= tuple.EPSILON {
+			ts = append(ts, -cc/(2*b))
+		}
+	} else {
+		discriminant := b*b - 4*a*cc
+		if discriminant >= 0 {
+			t0 := (-b - math.Sqrt(discriminant)) / (2 * a)
+			t1 := (-b + math.Sqrt(discriminant)) / (2 * a)
+			if t0 > t1 {
+				t0, t1 = t1, t0
+			}
+			ts = append(ts, t0, t1)
+		}
+	}
+
+	for _, t := range ts {
+		y := o.Y + t*d.Y
+		if c.Minimum < y && y < c.Maximum {
+			xs = append(xs, t)
+		}
+	}
+
+	return c.intersectCaps(r, xs)
+}
+
+func (c Cone) intersectCaps(r ray.Ray, xs []float64) []float64 {
+	if !c.Closed || math.Abs(r.Direction.Y) < tuple.EPSILON {
+		return xs
+	}
+
+	for _, y := range []float64{c.Minimum, c.Maximum} {
+		t := (y - r.Origin.Y) / r.Direction.Y
+		if checkCap(r, t, math.Abs(y)) {
+			xs = append(xs, t)
+		}
+	}
+	return xs
+}
+
+func (c Cone) LocalNormalAt(p tuple.Tuple) tuple.Tuple {
+	dist := p.X*p.X + p.Z*p.Z
+	if dist < p.Y*p.Y && p.Y >= c.Maximum-tuple.EPSILON {
+		return tuple.Vector(0, 1, 0)
+	}
+	if dist < p.Y*p.Y && p.Y <= c.Minimum+tuple.EPSILON {
+		return tuple.Vector(0, -1, 0)
+	}
+
+	// the tip has no well-defined normal, so use the axis rather than
+	// returning a zero vector that can't be normalized
+	if dist < tuple.EPSILON && math.Abs(p.Y) < tuple.EPSILON {
+		return tuple.Vector(0, 1, 0)
+	}
+
+	y := math.Sqrt(dist)
+	if p.Y > 0 {
+		y = -y
+	}
+	return tuple.Vector(p.X, y, p.Z)
+}
+
+func (c Cone) Bounds() Bounds {
+	limit := math.Max(math.Abs(c.Minimum), math.Abs(c.Maximum))
+	return NewBounds(tuple.Point(-limit, c.Minimum, -limit), tuple.Point(limit, c.Maximum, limit))
+}
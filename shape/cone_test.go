@@ -0,0 +1,99 @@
+package shape_test
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/shape"
+	"github.com/kieron-pivotal/rays/tuple"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cone", func() {
+
+	var (
+		cone shape.Cone
+	)
+
+	BeforeEach(func() {
+		cone = shape.Cone{
+			Minimum: math.Inf(-1),
+			Maximum: math.Inf(1),
+		}
+	})
+
+	It("can be used as an object", func() {
+		o := shape.NewCone()
+		r := ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1))
+		xs := o.Intersect(r)
+		Expect(xs.Count()).To(Equal(2))
+		Expect(o.NormalAt(tuple.Point(1, 1, 0))).To(tuple.Equal(tuple.Vector(1, -1, 0).Normalize()))
+	})
+
+	Context("ray intersection", func() {
+
+		DescribeTable("ray strikes cone", func(origin, direction tuple.Tuple, t0, t1 float64) {
+			r := ray.New(origin, direction.Normalize())
+			xs := cone.LocalIntersect(r)
+			Expect(xs).To(HaveLen(2))
+			Expect(xs[0]).To(BeNumerically("~", t0, tuple.EPSILON))
+			Expect(xs[1]).To(BeNumerically("~", t1, tuple.EPSILON))
+		},
+
+			Entry("through the tip", tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1), 5.0, 5.0),
+			Entry("diagonal", tuple.Point(0, 0, -5), tuple.Vector(1, 1, 1), 8.66025, 8.66025),
+			Entry("both nappes", tuple.Point(1, 1, -5), tuple.Vector(-0.5, -1, 1), 4.55006, 49.44994),
+		)
+
+		It("intersects once with a ray parallel to one of its halves", func() {
+			r := ray.New(tuple.Point(0, 0, -1), tuple.Vector(0, 1, 1).Normalize())
+			xs := cone.LocalIntersect(r)
+			Expect(xs).To(HaveLen(1))
+			Expect(xs[0]).To(BeNumerically("~", 0.35355, tuple.EPSILON))
+		})
+
+		DescribeTable("intersecting the caps of a closed cone", func(origin, direction tuple.Tuple, count int) {
+			cone.Minimum = -0.5
+			cone.Maximum = 0.5
+			cone.Closed = true
+			r := ray.New(origin, direction.Normalize())
+			xs := cone.LocalIntersect(r)
+			Expect(xs).To(HaveLen(count))
+		},
+
+			Entry("misses", tuple.Point(0, 0, -5), tuple.Vector(0, 1, 0), 0),
+			Entry("through one cap and side", tuple.Point(0, 0, -0.25), tuple.Vector(0, 1, 1), 2),
+			Entry("through both caps and sides", tuple.Point(0, 0, -0.25), tuple.Vector(0, 1, 0), 4),
+		)
+	})
+
+	Context("normals", func() {
+		DescribeTable("normal on the side of a cone", func(p, normal tuple.Tuple) {
+			Expect(cone.LocalNormalAt(p)).To(tuple.Equal(normal))
+		},
+
+			Entry("upper nappe", tuple.Point(1, 1, 1), tuple.Vector(1, -math.Sqrt(2), 1)),
+			Entry("lower nappe", tuple.Point(-1, -1, 0), tuple.Vector(-1, 1, 0)),
+		)
+
+		It("has a usable normal at the tip", func() {
+			n := cone.LocalNormalAt(tuple.Point(0, 0, 0))
+			Expect(n.Magnitude()).To(BeNumerically("~", 1))
+		})
+
+		DescribeTable("normal on the end caps of a cone", func(p, normal tuple.Tuple) {
+			cone.Minimum = -1
+			cone.Maximum = 2
+			cone.Closed = true
+			Expect(cone.LocalNormalAt(p)).To(tuple.Equal(normal))
+		},
+
+			Entry("bottom centre", tuple.Point(0, -1, 0), tuple.Vector(0, -1, 0)),
+			Entry("bottom off-centre", tuple.Point(0.5, -1, 0), tuple.Vector(0, -1, 0)),
+			Entry("top centre", tuple.Point(0, 2, 0), tuple.Vector(0, 1, 0)),
+			Entry("top off-centre", tuple.Point(1, 2, 0.5), tuple.Vector(0, 1, 0)),
+		)
+	})
+})
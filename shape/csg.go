@@ -0,0 +1,87 @@
+package shape
+
+import (
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/tuple"
+)
+
+type CSGOperation int
+
+const (
+	CSGUnion CSGOperation = iota
+	CSGIntersection
+	CSGDifference
+)
+
+type CSG struct {
+	Operation CSGOperation
+	Left      *Object
+	Right     *Object
+}
+
+func NewCSG(op CSGOperation, left, right *Object) *Object {
+	o := New(&CSG{
+		Operation: op,
+		Left:      left,
+		Right:     right,
+	})
+	left.parent = o
+	right.parent = o
+	return o
+}
+
+func (c *CSG) Name() string {
+	return "CSG"
+}
+
+func (c *CSG) LocalIntersect(r ray.Ray) []float64 {
+	return []float64{}
+}
+
+func (c *CSG) LocalNormalAt(tuple.Tuple) tuple.Tuple {
+	panic("a CSG has no normal; normals come from its children")
+}
+
+func (c *CSG) intersectChildren(r ray.Ray) *Intersections {
+	xs := c.Left.Intersect(r)
+	xs.Merge(c.Right.Intersect(r))
+	return c.filter(xs)
+}
+
+func (c *CSG) includes(obj *Object) bool {
+	return c.Left.Includes(obj) || c.Right.Includes(obj)
+}
+
+func (c *CSG) Bounds() Bounds {
+	return c.Left.ParentSpaceBounds().Merge(c.Right.ParentSpaceBounds())
+}
+
+func (c *CSG) filter(xs *Intersections) *Intersections {
+	res := NewIntersections()
+	inLeft, inRight := false, false
+
+	for _, x := range xs.list {
+		leftHit := c.Left.Includes(x.Object)
+		if IntersectionAllowed(c.Operation, leftHit, inLeft, inRight) {
+			res.list = append(res.list, x)
+		}
+		if leftHit {
+			inLeft = !inLeft
+		} else {
+			inRight = !inRight
+		}
+	}
+	return res
+}
+
+func IntersectionAllowed(op CSGOperation, leftHit, inLeft, inRight bool) bool {
+	switch op {
+	case CSGUnion:
+		return (leftHit && !inRight) || (!leftHit && !inLeft)
+	case CSGIntersection:
+		return (leftHit && inRight) || (!leftHit && inLeft)
+	case CSGDifference:
+		return (leftHit && !inRight) || (!leftHit && inLeft)
+	}
+	return false
+}
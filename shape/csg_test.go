@@ -0,0 +1,117 @@
+package shape_test
+
+import (
+	"github.com/kieron-pivotal/rays/matrix"
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/shape"
+	"github.com/kieron-pivotal/rays/tuple"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CSG", func() {
+
+	It("is created from an operation and two shapes", func() {
+		s1 := shape.NewSphere()
+		s2 := shape.NewCube()
+		c := shape.NewCSG(shape.CSGUnion, s1, s2)
+		csg := c.LocalObject().(*shape.CSG)
+		Expect(csg.Operation).To(Equal(shape.CSGUnion))
+		Expect(csg.Left).To(Equal(s1))
+		Expect(csg.Right).To(Equal(s2))
+		Expect(s1.Parent()).To(Equal(c))
+		Expect(s2.Parent()).To(Equal(c))
+	})
+
+	DescribeTable("evaluating the rule for a CSG operation",
+		func(op shape.CSGOperation, leftHit, inLeft, inRight, allowed bool) {
+			Expect(shape.IntersectionAllowed(op, leftHit, inLeft, inRight)).To(Equal(allowed))
+		},
+
+		Entry("union 1", shape.CSGUnion, true, true, true, false),
+		Entry("union 2", shape.CSGUnion, true, true, false, true),
+		Entry("union 3", shape.CSGUnion, true, false, true, false),
+		Entry("union 4", shape.CSGUnion, true, false, false, true),
+		Entry("union 5", shape.CSGUnion, false, true, true, false),
+		Entry("union 6", shape.CSGUnion, false, true, false, false),
+		Entry("union 7", shape.CSGUnion, false, false, true, true),
+		Entry("union 8", shape.CSGUnion, false, false, false, true),
+
+		Entry("intersection 1", shape.CSGIntersection, true, true, true, true),
+		Entry("intersection 2", shape.CSGIntersection, true, true, false, false),
+		Entry("intersection 3", shape.CSGIntersection, true, false, true, true),
+		Entry("intersection 4", shape.CSGIntersection, true, false, false, false),
+		Entry("intersection 5", shape.CSGIntersection, false, true, true, true),
+		Entry("intersection 6", shape.CSGIntersection, false, true, false, true),
+		Entry("intersection 7", shape.CSGIntersection, false, false, true, false),
+		Entry("intersection 8", shape.CSGIntersection, false, false, false, false),
+
+		Entry("difference 1", shape.CSGDifference, true, true, true, false),
+		Entry("difference 2", shape.CSGDifference, true, true, false, true),
+		Entry("difference 3", shape.CSGDifference, true, false, true, false),
+		Entry("difference 4", shape.CSGDifference, true, false, false, true),
+		Entry("difference 5", shape.CSGDifference, false, true, true, true),
+		Entry("difference 6", shape.CSGDifference, false, true, false, true),
+		Entry("difference 7", shape.CSGDifference, false, false, true, false),
+		Entry("difference 8", shape.CSGDifference, false, false, false, false),
+	)
+
+	DescribeTable("filtering intersections of two overlapping spheres",
+		func(op shape.CSGOperation, x0, x1 int) {
+			s1 := shape.NewSphere()
+			s2 := shape.NewSphere()
+			s2.SetTransform(matrix.Translation(0, 0, 0.5))
+			c := shape.NewCSG(op, s1, s2)
+
+			r := ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1))
+			all := []float64{4, 4.5, 6, 6.5}
+			xs := c.Intersect(r)
+			Expect(xs.Count()).To(Equal(2))
+			Expect(xs.Get(0).T).To(BeNumerically("~", all[x0]))
+			Expect(xs.Get(1).T).To(BeNumerically("~", all[x1]))
+		},
+
+		Entry("union", shape.CSGUnion, 0, 3),
+		Entry("intersection", shape.CSGIntersection, 1, 2),
+		Entry("difference", shape.CSGDifference, 0, 1),
+	)
+
+	It("misses when the ray misses both children", func() {
+		c := shape.NewCSG(shape.CSGUnion, shape.NewSphere(), shape.NewCube())
+		r := ray.New(tuple.Point(0, 2, -5), tuple.Vector(0, 0, 1))
+		Expect(c.Intersect(r).Count()).To(Equal(0))
+	})
+
+	It("applies its transform and reports the child objects", func() {
+		s1 := shape.NewSphere()
+		s2 := shape.NewSphere()
+		s2.SetTransform(matrix.Translation(0, 0, 0.5))
+		c := shape.NewCSG(shape.CSGUnion, s1, s2)
+		c.SetTransform(matrix.Translation(0, 0, 1))
+
+		r := ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1))
+		xs := c.Intersect(r)
+		Expect(xs.Count()).To(Equal(2))
+		Expect(xs.Get(0).T).To(BeNumerically("~", 5))
+		Expect(xs.Get(0).Object).To(Equal(s1))
+		Expect(xs.Get(1).T).To(BeNumerically("~", 7.5))
+		Expect(xs.Get(1).Object).To(Equal(s2))
+	})
+
+	It("treats shapes nested in groups as part of the side they are on", func() {
+		s1 := shape.NewSphere()
+		g := shape.NewGroup()
+		s2 := shape.NewSphere()
+		s2.SetTransform(matrix.Translation(0, 0, 0.5))
+		g.AddChild(s2)
+		c := shape.NewCSG(shape.CSGDifference, s1, g)
+
+		Expect(c.Includes(s2)).To(BeTrue())
+		r := ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1))
+		xs := c.Intersect(r)
+		Expect(xs.Count()).To(Equal(2))
+		Expect(xs.Get(0).T).To(BeNumerically("~", 4))
+		Expect(xs.Get(1).T).To(BeNumerically("~", 4.5))
+	})
+})
@@ -60,3 +60,7 @@ func (c Cube) LocalNormalAt(p tuple.Tuple) tuple.Tuple {
 	}
 	return tuple.Vector(0, 0, p.Z)
 }
+
+func (c Cube) Bounds() Bounds {
+	return NewBounds(tuple.Point(-1, -1, -1), tuple.Point(1, 1, 1))
+}
@@ -0,0 +1,97 @@
+package shape
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/tuple"
+)
+
+type Cylinder struct {
+	Minimum float64
+	Maximum float64
+	Closed  bool
+}
+
+func NewCylinder() *Object {
+	return New(Cylinder{
+		Minimum: math.Inf(-1),
+		Maximum: math.Inf(1),
+	})
+}
+
+func NewTruncatedCylinder(min, max float64, closed bool) *Object {
+	return New(Cylinder{
+		Minimum: min,
+		Maximum: max,
+		Closed:  closed,
+	})
+}
+
+func (c Cylinder) Name() string {
+	return "Cylinder"
+}
+
+func (c Cylinder) LocalIntersect(r ray.Ray) []float64 {
+	xs := []float64{}
+
+	a := r.Direction.X*r.Direction.X + r.Direction.Z*r.Direction.Z
+	if math.Abs(a) > tuple.EPSILON {
+		b := 2*r.Origin.X*r.Direction.X + 2*r.Origin.Z*r.Direction.Z
+		cc := r.Origin.X*r.Origin.X + r.Origin.Z*r.Origin.Z - 1
+		discriminant := b*b - 4*a*cc
+		if discriminant < 0 {
+			return xs
+		}
+
+		t0 := (-b - math.Sqrt(discriminant)) / (2 * a)
+		t1 := (-b + math.Sqrt(discriminant)) / (2 * a)
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+
+		for _, t := range []float64{t0, t1} {
+			y := r.Origin.Y + t*r.Direction.Y
+			if c.Minimum < y && y < c.Maximum {
+				xs = append(xs, t)
+			}
+		}
+	}
+
+	return c.intersectCaps(r, xs)
+}
+
+func (c Cylinder) intersectCaps(r ray.Ray, xs []float64) []float64 {
+	if !c.Closed || math.Abs(r.Direction.Y) < tuple.EPSILON {
+		return xs
+	}
+
+	for _, y := range []float64{c.Minimum, c.Maximum} {
+		t := (y - r.Origin.Y) / r.Direction.Y
+		if checkCap(r, t, 1) {
+			xs = append(xs, t)
+		}
+	}
+	return xs
+}
+
+func checkCap(r ray.Ray, t, radius float64) bool {
+	x := r.Origin.X + t*r.Direction.X
+	z := r.Origin.Z + t*r.Direction.Z
+	return x*x+z*z <= radius*radius+tuple.EPSILON
+}
+
+func (c Cylinder) LocalNormalAt(p tuple.Tuple) tuple.Tuple {
+	dist := p.X*p.X + p.Z*p.Z
+	if dist < 1 && p.Y >= c.Maximum-tuple.EPSILON {
+		return tuple.Vector(0, 1, 0)
+	}
+	if dist < 1 && p.Y <= c.Minimum+tuple.EPSILON {
+		return tuple.Vector(0, -1, 0)
+	}
+	return tuple.Vector(p.X, 0, p.Z)
+}
+
+func (c Cylinder) Bounds() Bounds {
+	return NewBounds(tuple.Point(-1, c.Minimum, -1), tuple.Point(1, c.Maximum, 1))
+}
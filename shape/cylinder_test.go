@@ -0,0 +1,118 @@
+package shape_test
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/shape"
+	"github.com/kieron-pivotal/rays/tuple"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cylinder", func() {
+
+	var (
+		cyl shape.Cylinder
+	)
+
+	BeforeEach(func() {
+		cyl = shape.Cylinder{
+			Minimum: math.Inf(-1),
+			Maximum: math.Inf(1),
+		}
+	})
+
+	It("is unbounded by default", func() {
+		o := shape.NewCylinder()
+		r := ray.New(tuple.Point(0, 1000, -5), tuple.Vector(0, 0, 1))
+		Expect(o.Intersect(r).Count()).To(Equal(2))
+	})
+
+	Context("ray intersection", func() {
+
+		DescribeTable("ray misses cylinder", func(origin, direction tuple.Tuple) {
+			r := ray.New(origin, direction.Normalize())
+			xs := cyl.LocalIntersect(r)
+			Expect(xs).To(BeEmpty())
+		},
+
+			Entry("on the surface, parallel to y", tuple.Point(1, 0, 0), tuple.Vector(0, 1, 0)),
+			Entry("inside, parallel to y", tuple.Point(0, 0, 0), tuple.Vector(0, 1, 0)),
+			Entry("outside, skewed", tuple.Point(0, 0, -5), tuple.Vector(1, 1, 1)),
+		)
+
+		DescribeTable("ray strikes cylinder", func(origin, direction tuple.Tuple, t0, t1 float64) {
+			r := ray.New(origin, direction.Normalize())
+			xs := cyl.LocalIntersect(r)
+			Expect(xs).To(HaveLen(2))
+			Expect(xs[0]).To(BeNumerically("~", t0, tuple.EPSILON))
+			Expect(xs[1]).To(BeNumerically("~", t1, tuple.EPSILON))
+		},
+
+			Entry("tangent", tuple.Point(1, 0, -5), tuple.Vector(0, 0, 1), 5.0, 5.0),
+			Entry("through the middle", tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1), 4.0, 6.0),
+			Entry("at an angle", tuple.Point(0.5, 0, -5), tuple.Vector(0.1, 1, 1), 6.80798, 7.08872),
+		)
+
+		DescribeTable("intersecting a truncated cylinder", func(origin, direction tuple.Tuple, count int) {
+			cyl.Minimum = 1
+			cyl.Maximum = 2
+			r := ray.New(origin, direction.Normalize())
+			xs := cyl.LocalIntersect(r)
+			Expect(xs).To(HaveLen(count))
+		},
+
+			Entry("diagonal from inside", tuple.Point(0, 1.5, 0), tuple.Vector(0.1, 1, 0), 0),
+			Entry("above", tuple.Point(0, 3, -5), tuple.Vector(0, 0, 1), 0),
+			Entry("below", tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1), 0),
+			Entry("at the maximum", tuple.Point(0, 2, -5), tuple.Vector(0, 0, 1), 0),
+			Entry("at the minimum", tuple.Point(0, 1, -5), tuple.Vector(0, 0, 1), 0),
+			Entry("through the middle", tuple.Point(0, 1.5, -2), tuple.Vector(0, 0, 1), 2),
+		)
+
+		DescribeTable("intersecting the caps of a closed cylinder", func(origin, direction tuple.Tuple, count int) {
+			cyl.Minimum = 1
+			cyl.Maximum = 2
+			cyl.Closed = true
+			r := ray.New(origin, direction.Normalize())
+			xs := cyl.LocalIntersect(r)
+			Expect(xs).To(HaveLen(count))
+		},
+
+			Entry("down through both caps", tuple.Point(0, 3, 0), tuple.Vector(0, -1, 0), 2),
+			Entry("through the top cap and side", tuple.Point(0, 3, -2), tuple.Vector(0, -1, 2), 2),
+			Entry("top cap corner case", tuple.Point(0, 4, -2), tuple.Vector(0, -1, 1), 2),
+			Entry("through the bottom cap and side", tuple.Point(0, 0, -2), tuple.Vector(0, 1, 2), 2),
+			Entry("bottom cap corner case", tuple.Point(0, -1, -2), tuple.Vector(0, 1, 1), 2),
+		)
+	})
+
+	Context("normals", func() {
+		DescribeTable("normal on the side of a cylinder", func(p, normal tuple.Tuple) {
+			Expect(cyl.LocalNormalAt(p)).To(tuple.Equal(normal))
+		},
+
+			Entry("+x", tuple.Point(1, 0, 0), tuple.Vector(1, 0, 0)),
+			Entry("-z", tuple.Point(0, 5, -1), tuple.Vector(0, 0, -1)),
+			Entry("+z", tuple.Point(0, -2, 1), tuple.Vector(0, 0, 1)),
+			Entry("-x", tuple.Point(-1, 1, 0), tuple.Vector(-1, 0, 0)),
+		)
+
+		DescribeTable("normal on the end caps of a cylinder", func(p, normal tuple.Tuple) {
+			cyl.Minimum = 1
+			cyl.Maximum = 2
+			cyl.Closed = true
+			Expect(cyl.LocalNormalAt(p)).To(tuple.Equal(normal))
+		},
+
+			Entry("bottom centre", tuple.Point(0, 1, 0), tuple.Vector(0, -1, 0)),
+			Entry("bottom x", tuple.Point(0.5, 1, 0), tuple.Vector(0, -1, 0)),
+			Entry("bottom z", tuple.Point(0, 1, 0.5), tuple.Vector(0, -1, 0)),
+			Entry("top centre", tuple.Point(0, 2, 0), tuple.Vector(0, 1, 0)),
+			Entry("top x", tuple.Point(0.5, 2, 0), tuple.Vector(0, 1, 0)),
+			Entry("top z", tuple.Point(0, 2, 0.5), tuple.Vector(0, 1, 0)),
+		)
+	})
+})
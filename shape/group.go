@@ -0,0 +1,51 @@
+package shape
+
+import (
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/tuple"
+)
+
+type Group struct {
+	children []*Object
+}
+
+func NewGroup() *Object {
+	return New(&Group{})
+}
+
+func (g *Group) Name() string {
+	return "Group"
+}
+
+func (g *Group) LocalIntersect(r ray.Ray) []float64 {
+	return []float64{}
+}
+
+func (g *Group) LocalNormalAt(tuple.Tuple) tuple.Tuple {
+	panic("a group has no normal; normals come from its children")
+}
+
+func (g *Group) intersectChildren(r ray.Ray) *Intersections {
+	res := NewIntersections()
+	for _, child := range g.children {
+		res.Merge(child.Intersect(r))
+	}
+	return res
+}
+
+func (g *Group) includes(obj *Object) bool {
+	for _, child := range g.children {
+		if child.Includes(obj) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Group) Bounds() Bounds {
+	b := EmptyBounds()
+	for _, child := range g.children {
+		b = b.Merge(child.ParentSpaceBounds())
+	}
+	return b
+}
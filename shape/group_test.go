@@ -0,0 +1,113 @@
+package shape_test
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/matrix"
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/shape"
+	"github.com/kieron-pivotal/rays/shape/shapefakes"
+	"github.com/kieron-pivotal/rays/tuple"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Group", func() {
+
+	var (
+		g *shape.Object
+	)
+
+	BeforeEach(func() {
+		g = shape.NewGroup()
+	})
+
+	It("starts empty with the identity transform", func() {
+		Expect(g.GetTransform()).To(matrix.Equal(matrix.Identity(4, 4)))
+		Expect(g.Children()).To(BeEmpty())
+	})
+
+	It("can have children added", func() {
+		s := shape.New(new(shapefakes.FakeLocalObject))
+		g.AddChild(s)
+		Expect(g.Children()).To(ConsistOf(s))
+		Expect(s.Parent()).To(Equal(g))
+	})
+
+	It("refuses to add children to a non-group", func() {
+		s := shape.NewSphere()
+		Expect(func() { s.AddChild(shape.NewSphere()) }).To(Panic())
+	})
+
+	Context("intersection", func() {
+		It("doesn't intersect when empty", func() {
+			r := ray.New(tuple.Point(0, 0, 0), tuple.Vector(0, 0, 1))
+			Expect(g.Intersect(r).Count()).To(Equal(0))
+		})
+
+		It("intersects its children", func() {
+			s1 := shape.NewSphere()
+			s2 := shape.NewSphere()
+			s2.SetTransform(matrix.Translation(0, 0, -3))
+			s3 := shape.NewSphere()
+			s3.SetTransform(matrix.Translation(5, 0, 0))
+			g.AddChild(s1)
+			g.AddChild(s2)
+			g.AddChild(s3)
+
+			r := ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1))
+			xs := g.Intersect(r)
+			Expect(xs.Count()).To(Equal(4))
+			Expect(xs.Get(0).Object).To(Equal(s2))
+			Expect(xs.Get(1).Object).To(Equal(s2))
+			Expect(xs.Get(2).Object).To(Equal(s1))
+			Expect(xs.Get(3).Object).To(Equal(s1))
+		})
+
+		It("applies its transform to its children", func() {
+			g.SetTransform(matrix.Scaling(2, 2, 2))
+			s := shape.NewSphere()
+			s.SetTransform(matrix.Translation(5, 0, 0))
+			g.AddChild(s)
+
+			r := ray.New(tuple.Point(10, 0, -10), tuple.Vector(0, 0, 1))
+			xs := g.Intersect(r)
+			Expect(xs.Count()).To(Equal(2))
+		})
+	})
+
+	Context("space conversion", func() {
+		var (
+			g2 *shape.Object
+			s  *shape.Object
+		)
+
+		BeforeEach(func() {
+			g.SetTransform(matrix.RotationY(math.Pi / 2))
+			g2 = shape.NewGroup()
+			g2.SetTransform(matrix.Scaling(2, 2, 2))
+			g.AddChild(g2)
+			s = shape.NewSphere()
+			s.SetTransform(matrix.Translation(5, 0, 0))
+			g2.AddChild(s)
+		})
+
+		It("converts a point from world to object space", func() {
+			p := s.WorldToObject(tuple.Point(-2, 0, -10))
+			Expect(p).To(tuple.Equal(tuple.Point(0, 0, -1)))
+		})
+
+		It("converts a normal from object to world space", func() {
+			g2.SetTransform(matrix.Scaling(1, 2, 3))
+			r3 := math.Sqrt(3)
+			n := s.NormalToWorld(tuple.Vector(r3/3, r3/3, r3/3))
+			Expect(n).To(tuple.Equal(tuple.Vector(0.28571, 0.42857, -0.85714)))
+		})
+
+		It("finds the normal on a child object", func() {
+			g2.SetTransform(matrix.Scaling(1, 2, 3))
+			n := s.NormalAt(tuple.Point(1.7321, 1.1547, -5.5774))
+			Expect(n).To(tuple.Equal(tuple.Vector(0.28570, 0.42854, -0.85716)))
+		})
+	})
+})
@@ -15,6 +15,8 @@ type Intersections struct {
 
 type Intersection struct {
 	T      float64
+	U      float64
+	V      float64
 	Object *Object
 }
 
@@ -62,10 +64,53 @@ func (i Intersections) Get(idx int) *Intersection {
 }
 
 func (i *Intersections) Add(t float64, s *Object) {
-	i.list = append(i.list, &Intersection{T: t, Object: s})
-	sort.Slice(i.list, func(a, b int) bool {
-		return i.list[a].T < i.list[b].T
+	i.AddWithUV(t, 0, 0, s)
+}
+
+func (i *Intersections) AddWithUV(t, u, v float64, s *Object) {
+	x := &Intersection{T: t, U: u, V: v, Object: s}
+	idx := sort.Search(len(i.list), func(j int) bool {
+		return i.list[j].T > t
 	})
+	i.list = append(i.list, nil)
+	copy(i.list[idx+1:], i.list[idx:])
+	i.list[idx] = x
+}
+
+func (i *Intersections) Merge(xs *Intersections) {
+	if xs.Count() == 0 {
+		return
+	}
+	if i.Count() == 0 {
+		i.list = append(i.list, xs.list...)
+		return
+	}
+
+	merged := make([]*Intersection, 0, len(i.list)+len(xs.list))
+	a, b := 0, 0
+	for a < len(i.list) && b < len(xs.list) {
+		if i.list[a].T <= xs.list[b].T {
+			merged = append(merged, i.list[a])
+			a++
+		} else {
+			merged = append(merged, xs.list[b])
+			b++
+		}
+	}
+	merged = append(merged, i.list[a:]...)
+	merged = append(merged, xs.list[b:]...)
+	i.list = merged
+}
+
+// Filter returns the intersections whose objects pass keep, still in order.
+func (i *Intersections) Filter(keep func(*Object) bool) *Intersections {
+	res := NewIntersections()
+	for _, x := range i.list {
+		if keep(x.Object) {
+			res.list = append(res.list, x)
+		}
+	}
+	return res
 }
 
 func (i *Intersections) Hit() *Intersection {
@@ -83,7 +128,7 @@ func (i *Intersection) PrepareComputations(r ray.Ray, xs *Intersections) Computa
 	c.Object = i.Object
 	c.Point = r.Position(c.T)
 	c.EyeV = r.Direction.Multiply(-1)
-	c.NormalV = c.Object.NormalAt(c.Point)
+	c.NormalV = c.Object.NormalAt(c.Point, i)
 	c.Inside = c.EyeV.Dot(c.NormalV) < 0
 	if c.Inside {
 		c.NormalV = c.NormalV.Multiply(-1)
@@ -54,6 +54,41 @@ var _ = Describe("Intersection", func() {
 		Expect(i.T).To(BeNumerically("~", 2))
 	})
 
+	It("can carry u and v", func() {
+		ix.AddWithUV(3.5, 0.2, 0.4, s)
+		i := ix.Get(0)
+		Expect(i.T).To(BeNumerically("~", 3.5))
+		Expect(i.U).To(BeNumerically("~", 0.2))
+		Expect(i.V).To(BeNumerically("~", 0.4))
+	})
+
+	It("can merge in another set of intersections in order", func() {
+		ix.Add(1, s)
+		ix.Add(4, s)
+		other := shape.NewIntersections()
+		other.AddWithUV(2, 0.1, 0.2, s)
+		other.Add(3, s)
+		ix.Merge(other)
+		Expect(ix.Count()).To(Equal(4))
+		for j, t := range []float64{1, 2, 3, 4} {
+			Expect(ix.Get(j).T).To(BeNumerically("~", t))
+		}
+		Expect(ix.Get(1).U).To(BeNumerically("~", 0.1))
+	})
+
+	It("can filter intersections by object, keeping their order", func() {
+		other := shape.NewSphere()
+		ix.Add(3, s)
+		ix.Add(1, other)
+		ix.Add(2, s)
+		ix.Add(4, other)
+		filtered := ix.Filter(func(o *shape.Object) bool { return o == other })
+		Expect(filtered.Count()).To(Equal(2))
+		Expect(filtered.Get(0).T).To(BeNumerically("~", 1))
+		Expect(filtered.Get(1).T).To(BeNumerically("~", 4))
+		Expect(ix.Count()).To(Equal(4))
+	})
+
 	Context("PrepareComputations", func() {
 		It("can prepare common details", func() {
 			o := shape.NewSphere()
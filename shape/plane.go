@@ -29,3 +29,8 @@ func (p Plane) LocalIntersect(r ray.Ray) []float64 {
 func (p Plane) LocalNormalAt(tuple.Tuple) tuple.Tuple {
 	return tuple.Vector(0, 1, 0)
 }
+
+func (p Plane) Bounds() Bounds {
+	inf := math.Inf(1)
+	return NewBounds(tuple.Point(-inf, 0, -inf), tuple.Point(inf, 0, inf))
+}
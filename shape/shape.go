@@ -1,6 +1,7 @@
 package shape
 
 import (
+	"fmt"
 	"sync/atomic"
 
 	"github.com/kieron-pivotal/rays/material"
@@ -21,6 +22,20 @@ type LocalObject interface {
 	Name() string
 	LocalIntersect(ray.Ray) []float64
 	LocalNormalAt(tuple.Tuple) tuple.Tuple
+	Bounds() Bounds
+}
+
+type childIntersecter interface {
+	intersectChildren(ray.Ray) *Intersections
+	includes(*Object) bool
+}
+
+type UVIntersecter interface {
+	LocalIntersectWithUV(ray.Ray) []Intersection
+}
+
+type UVNormaler interface {
+	LocalNormalAtUV(p tuple.Tuple, u, v float64) tuple.Tuple
 }
 
 type Object struct {
@@ -30,6 +45,10 @@ type Object struct {
 	transposeTransform matrix.Matrix
 	material           material.Material
 	localObject        LocalObject
+	parent             *Object
+	castsShadow        bool
+	visibleToCamera    bool
+	visibleInReflected bool
 }
 
 func New(obj LocalObject) *Object {
@@ -40,25 +59,103 @@ func New(obj LocalObject) *Object {
 		transposeTransform: matrix.Identity(4, 4),
 		material:           material.New(),
 		localObject:        obj,
+		castsShadow:        true,
+		visibleToCamera:    true,
+		visibleInReflected: true,
 	}
 	return &o
 }
 
 func (o *Object) Intersect(ray ray.Ray) *Intersections {
 	ray2 := ray.Transform(o.inverseTransform)
+	if c, ok := o.localObject.(childIntersecter); ok {
+		return c.intersectChildren(ray2)
+	}
 	res := NewIntersections()
+	if uv, ok := o.localObject.(UVIntersecter); ok {
+		for _, x := range uv.LocalIntersectWithUV(ray2) {
+			res.AddWithUV(x.T, x.U, x.V, o)
+		}
+		return res
+	}
 	for _, t := range o.localObject.LocalIntersect(ray2) {
 		res.Add(t, o)
 	}
 	return res
 }
 
-func (o *Object) NormalAt(p tuple.Tuple) tuple.Tuple {
-	objPoint := o.inverseTransform.TupleMultiply(p)
-	objNormal := o.localObject.LocalNormalAt(objPoint)
-	worldNormal := o.transposeTransform.TupleMultiply(objNormal)
-	worldNormal.W = 0
-	return worldNormal.Normalize()
+func (o *Object) NormalAt(p tuple.Tuple, optHit ...*Intersection) tuple.Tuple {
+	objPoint := o.WorldToObject(p)
+	var objNormal tuple.Tuple
+	if uv, ok := o.localObject.(UVNormaler); ok && len(optHit) == 1 {
+		objNormal = uv.LocalNormalAtUV(objPoint, optHit[0].U, optHit[0].V)
+	} else {
+		objNormal = o.localObject.LocalNormalAt(objPoint)
+	}
+	return o.NormalToWorld(objNormal)
+}
+
+func (o *Object) WorldToObject(p tuple.Tuple) tuple.Tuple {
+	if o.parent != nil {
+		p = o.parent.WorldToObject(p)
+	}
+	return o.inverseTransform.TupleMultiply(p)
+}
+
+func (o *Object) NormalToWorld(n tuple.Tuple) tuple.Tuple {
+	n = o.transposeTransform.TupleMultiply(n)
+	n.W = 0
+	n = n.Normalize()
+	if o.parent != nil {
+		n = o.parent.NormalToWorld(n)
+	}
+	return n
+}
+
+func (o *Object) Parent() *Object {
+	return o.parent
+}
+
+func (o *Object) AddChild(child *Object) {
+	g, ok := o.localObject.(*Group)
+	if !ok {
+		panic(fmt.Sprintf("cannot add a child to a %s", o.localObject.Name()))
+	}
+	g.children = append(g.children, child)
+	child.parent = o
+}
+
+func (o *Object) Includes(other *Object) bool {
+	if o == other {
+		return true
+	}
+	if c, ok := o.localObject.(childIntersecter); ok {
+		return c.includes(other)
+	}
+	return false
+}
+
+func (o *Object) Bounds() Bounds {
+	return o.localObject.Bounds()
+}
+
+func (o *Object) ParentSpaceBounds() Bounds {
+	return o.Bounds().Transform(o.transform)
+}
+
+func (o *Object) WorldSpaceBounds() Bounds {
+	b := o.ParentSpaceBounds()
+	for p := o.parent; p != nil; p = p.parent {
+		b = b.Transform(p.transform)
+	}
+	return b
+}
+
+func (o *Object) Children() []*Object {
+	if g, ok := o.localObject.(*Group); ok {
+		return g.children
+	}
+	return nil
 }
 
 func (o *Object) SetTransform(t matrix.Matrix) {
@@ -75,6 +172,10 @@ func (o *Object) GetInverseTransform() matrix.Matrix {
 	return o.inverseTransform
 }
 
+func (o *Object) LocalObject() LocalObject {
+	return o.localObject
+}
+
 func (o *Object) Material() material.Material {
 	return o.material
 }
@@ -82,3 +183,33 @@ func (o *Object) Material() material.Material {
 func (o *Object) SetMaterial(m material.Material) {
 	o.material = m
 }
+
+// CastsShadow reports whether the object blocks light. Turning it off on a
+// group turns it off for everything in the group.
+func (o *Object) CastsShadow() bool {
+	return o.castsShadow && (o.parent == nil || o.parent.CastsShadow())
+}
+
+func (o *Object) SetCastsShadow(casts bool) {
+	o.castsShadow = casts
+}
+
+// VisibleToCamera reports whether camera rays can hit the object, which
+// still casts shadows and shows in reflections when it can't.
+func (o *Object) VisibleToCamera() bool {
+	return o.visibleToCamera && (o.parent == nil || o.parent.VisibleToCamera())
+}
+
+func (o *Object) SetVisibleToCamera(visible bool) {
+	o.visibleToCamera = visible
+}
+
+// VisibleInReflected reports whether reflected and refracted rays can hit
+// the object.
+func (o *Object) VisibleInReflected() bool {
+	return o.visibleInReflected && (o.parent == nil || o.parent.VisibleInReflected())
+}
+
+func (o *Object) SetVisibleInReflected(visible bool) {
+	o.visibleInReflected = visible
+}
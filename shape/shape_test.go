@@ -80,6 +80,36 @@ var _ = Describe("Shape", func() {
 		})
 	})
 
+	Context("bounds", func() {
+		BeforeEach(func() {
+			localObject.BoundsReturns(shape.NewBounds(tuple.Point(-1, -1, -1), tuple.Point(1, 1, 1)))
+		})
+
+		It("reports the bounds of the local object in object space", func() {
+			s.SetTransform(matrix.Scaling(2, 2, 2))
+			b := s.Bounds()
+			Expect(b.Min).To(tuple.Equal(tuple.Point(-1, -1, -1)))
+			Expect(b.Max).To(tuple.Equal(tuple.Point(1, 1, 1)))
+		})
+
+		It("transforms its bounds into parent space", func() {
+			s.SetTransform(matrix.Scaling(0.5, 2, 4).Translate(1, -3, 5))
+			b := s.ParentSpaceBounds()
+			Expect(b.Min).To(tuple.Equal(tuple.Point(0.5, -5, 1)))
+			Expect(b.Max).To(tuple.Equal(tuple.Point(1.5, -1, 9)))
+		})
+
+		It("transforms its bounds into world space through its parents", func() {
+			s.SetTransform(matrix.Translation(1, 0, 0))
+			g := shape.NewGroup()
+			g.SetTransform(matrix.Scaling(2, 2, 2))
+			g.AddChild(s)
+			b := s.WorldSpaceBounds()
+			Expect(b.Min).To(tuple.Equal(tuple.Point(0, -2, -2)))
+			Expect(b.Max).To(tuple.Equal(tuple.Point(4, 2, 2)))
+		})
+	})
+
 	Context("normals", func() {
 		It("calls the local normal function on the local object", func() {
 			s.SetTransform(matrix.Translation(0, 1, 0))
@@ -111,4 +141,41 @@ var _ = Describe("Shape", func() {
 				tuple.Vector(0, 0.97014, -0.24254)),
 		)
 	})
+
+	Context("visibility flags", func() {
+		It("is visible everywhere and casts shadows by default", func() {
+			o := shape.NewSphere()
+			Expect(o.CastsShadow()).To(BeTrue())
+			Expect(o.VisibleToCamera()).To(BeTrue())
+			Expect(o.VisibleInReflected()).To(BeTrue())
+		})
+
+		It("can turn each flag off separately", func() {
+			o := shape.NewSphere()
+			o.SetCastsShadow(false)
+			Expect(o.CastsShadow()).To(BeFalse())
+			Expect(o.VisibleToCamera()).To(BeTrue())
+
+			o.SetVisibleToCamera(false)
+			Expect(o.VisibleToCamera()).To(BeFalse())
+			Expect(o.VisibleInReflected()).To(BeTrue())
+
+			o.SetVisibleInReflected(false)
+			Expect(o.VisibleInReflected()).To(BeFalse())
+		})
+
+		It("inherits flags turned off on a containing group", func() {
+			outer := shape.NewGroup()
+			inner := shape.NewGroup()
+			o := shape.NewSphere()
+			outer.AddChild(inner)
+			inner.AddChild(o)
+
+			outer.SetVisibleToCamera(false)
+			inner.SetCastsShadow(false)
+			Expect(o.VisibleToCamera()).To(BeFalse())
+			Expect(o.CastsShadow()).To(BeFalse())
+			Expect(o.VisibleInReflected()).To(BeTrue())
+		})
+	})
 })
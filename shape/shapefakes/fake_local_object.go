@@ -10,6 +10,16 @@ import (
 )
 
 type FakeLocalObject struct {
+	BoundsStub        func() shape.Bounds
+	boundsMutex       sync.RWMutex
+	boundsArgsForCall []struct {
+	}
+	boundsReturns struct {
+		result1 shape.Bounds
+	}
+	boundsReturnsOnCall map[int]struct {
+		result1 shape.Bounds
+	}
 	LocalIntersectStub        func(ray.Ray) []float64
 	localIntersectMutex       sync.RWMutex
 	localIntersectArgsForCall []struct {
@@ -46,21 +56,75 @@ type FakeLocalObject struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeLocalObject) Bounds() shape.Bounds {
+	fake.boundsMutex.Lock()
+	ret, specificReturn := fake.boundsReturnsOnCall[len(fake.boundsArgsForCall)]
+	fake.boundsArgsForCall = append(fake.boundsArgsForCall, struct {
+	}{})
+	stub := fake.BoundsStub
+	fakeReturns := fake.boundsReturns
+	fake.recordInvocation("Bounds", []interface{}{})
+	fake.boundsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLocalObject) BoundsCallCount() int {
+	fake.boundsMutex.RLock()
+	defer fake.boundsMutex.RUnlock()
+	return len(fake.boundsArgsForCall)
+}
+
+func (fake *FakeLocalObject) BoundsCalls(stub func() shape.Bounds) {
+	fake.boundsMutex.Lock()
+	defer fake.boundsMutex.Unlock()
+	fake.BoundsStub = stub
+}
+
+func (fake *FakeLocalObject) BoundsReturns(result1 shape.Bounds) {
+	fake.boundsMutex.Lock()
+	defer fake.boundsMutex.Unlock()
+	fake.BoundsStub = nil
+	fake.boundsReturns = struct {
+		result1 shape.Bounds
+	}{result1}
+}
+
+func (fake *FakeLocalObject) BoundsReturnsOnCall(i int, result1 shape.Bounds) {
+	fake.boundsMutex.Lock()
+	defer fake.boundsMutex.Unlock()
+	fake.BoundsStub = nil
+	if fake.boundsReturnsOnCall == nil {
+		fake.boundsReturnsOnCall = make(map[int]struct {
+			result1 shape.Bounds
+		})
+	}
+	fake.boundsReturnsOnCall[i] = struct {
+		result1 shape.Bounds
+	}{result1}
+}
+
 func (fake *FakeLocalObject) LocalIntersect(arg1 ray.Ray) []float64 {
 	fake.localIntersectMutex.Lock()
 	ret, specificReturn := fake.localIntersectReturnsOnCall[len(fake.localIntersectArgsForCall)]
 	fake.localIntersectArgsForCall = append(fake.localIntersectArgsForCall, struct {
 		arg1 ray.Ray
 	}{arg1})
+	stub := fake.LocalIntersectStub
+	fakeReturns := fake.localIntersectReturns
 	fake.recordInvocation("LocalIntersect", []interface{}{arg1})
 	fake.localIntersectMutex.Unlock()
-	if fake.LocalIntersectStub != nil {
-		return fake.LocalIntersectStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.localIntersectReturns
 	return fakeReturns.result1
 }
 
@@ -112,15 +176,16 @@ func (fake *FakeLocalObject) LocalNormalAt(arg1 tuple.Tuple) tuple.Tuple {
 	fake.localNormalAtArgsForCall = append(fake.localNormalAtArgsForCall, struct {
 		arg1 tuple.Tuple
 	}{arg1})
+	stub := fake.LocalNormalAtStub
+	fakeReturns := fake.localNormalAtReturns
 	fake.recordInvocation("LocalNormalAt", []interface{}{arg1})
 	fake.localNormalAtMutex.Unlock()
-	if fake.LocalNormalAtStub != nil {
-		return fake.LocalNormalAtStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.localNormalAtReturns
 	return fakeReturns.result1
 }
 
@@ -171,15 +236,16 @@ func (fake *FakeLocalObject) Name() string {
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
 	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
 	}{})
+	stub := fake.NameStub
+	fakeReturns := fake.nameReturns
 	fake.recordInvocation("Name", []interface{}{})
 	fake.nameMutex.Unlock()
-	if fake.NameStub != nil {
-		return fake.NameStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.nameReturns
 	return fakeReturns.result1
 }
 
@@ -221,6 +287,8 @@ func (fake *FakeLocalObject) NameReturnsOnCall(i int, result1 string) {
 func (fake *FakeLocalObject) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.boundsMutex.RLock()
+	defer fake.boundsMutex.RUnlock()
 	fake.localIntersectMutex.RLock()
 	defer fake.localIntersectMutex.RUnlock()
 	fake.localNormalAtMutex.RLock()
@@ -0,0 +1,31 @@
+package shape
+
+import (
+	"github.com/kieron-pivotal/rays/tuple"
+)
+
+type SmoothTriangle struct {
+	Triangle
+	N1 tuple.Tuple
+	N2 tuple.Tuple
+	N3 tuple.Tuple
+}
+
+func NewSmoothTriangle(p1, p2, p3, n1, n2, n3 tuple.Tuple) *Object {
+	return New(&SmoothTriangle{
+		Triangle: *newTriangle(p1, p2, p3),
+		N1:       n1,
+		N2:       n2,
+		N3:       n3,
+	})
+}
+
+func (t *SmoothTriangle) Name() string {
+	return "Smooth triangle"
+}
+
+func (t *SmoothTriangle) LocalNormalAtUV(p tuple.Tuple, u, v float64) tuple.Tuple {
+	return t.N2.Multiply(u).
+		Add(t.N3.Multiply(v)).
+		Add(t.N1.Multiply(1 - u - v))
+}
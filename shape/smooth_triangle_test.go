@@ -0,0 +1,58 @@
+package shape_test
+
+import (
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/shape"
+	"github.com/kieron-pivotal/rays/tuple"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Smooth triangle", func() {
+
+	var (
+		o *shape.Object
+		t *shape.SmoothTriangle
+	)
+
+	BeforeEach(func() {
+		o = shape.NewSmoothTriangle(
+			tuple.Point(0, 1, 0),
+			tuple.Point(-1, 0, 0),
+			tuple.Point(1, 0, 0),
+			tuple.Vector(0, 1, 0),
+			tuple.Vector(-1, 0, 0),
+			tuple.Vector(1, 0, 0),
+		)
+		t = o.LocalObject().(*shape.SmoothTriangle)
+	})
+
+	It("stores its vertex normals", func() {
+		Expect(t.N1).To(tuple.Equal(tuple.Vector(0, 1, 0)))
+		Expect(t.N2).To(tuple.Equal(tuple.Vector(-1, 0, 0)))
+		Expect(t.N3).To(tuple.Equal(tuple.Vector(1, 0, 0)))
+	})
+
+	It("stores u and v on an intersection", func() {
+		r := ray.New(tuple.Point(-0.2, 0.3, -2), tuple.Vector(0, 0, 1))
+		xs := o.Intersect(r)
+		Expect(xs.Count()).To(Equal(1))
+		Expect(xs.Get(0).U).To(BeNumerically("~", 0.45, tuple.EPSILON))
+		Expect(xs.Get(0).V).To(BeNumerically("~", 0.25, tuple.EPSILON))
+	})
+
+	It("interpolates the normal using u and v", func() {
+		xs := shape.NewIntersections()
+		xs.AddWithUV(1, 0.45, 0.25, o)
+		n := o.NormalAt(tuple.Point(0, 0, 0), xs.Get(0))
+		Expect(n).To(tuple.Equal(tuple.Vector(-0.5547, 0.83205, 0)))
+	})
+
+	It("uses the interpolated normal when preparing computations", func() {
+		xs := shape.NewIntersections()
+		xs.AddWithUV(1, 0.45, 0.25, o)
+		r := ray.New(tuple.Point(-0.2, 0.3, -2), tuple.Vector(0, 0, 1))
+		comps := xs.Get(0).PrepareComputations(r, xs)
+		Expect(comps.NormalV).To(tuple.Equal(tuple.Vector(-0.5547, 0.83205, 0)))
+	})
+})
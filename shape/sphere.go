@@ -46,3 +46,7 @@ func (s Sphere) LocalIntersect(ray ray.Ray) []float64 {
 func (s Sphere) LocalNormalAt(p tuple.Tuple) tuple.Tuple {
 	return p.Subtract(tuple.Point(0, 0, 0))
 }
+
+func (s Sphere) Bounds() Bounds {
+	return NewBounds(tuple.Point(-1, -1, -1), tuple.Point(1, 1, 1))
+}
@@ -0,0 +1,79 @@
+package shape
+
+import (
+	"math"
+
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/tuple"
+)
+
+type Triangle struct {
+	P1     tuple.Tuple
+	P2     tuple.Tuple
+	P3     tuple.Tuple
+	E1     tuple.Tuple
+	E2     tuple.Tuple
+	Normal tuple.Tuple
+}
+
+func NewTriangle(p1, p2, p3 tuple.Tuple) *Object {
+	return New(newTriangle(p1, p2, p3))
+}
+
+func newTriangle(p1, p2, p3 tuple.Tuple) *Triangle {
+	e1 := p2.Subtract(p1)
+	e2 := p3.Subtract(p1)
+	return &Triangle{
+		P1:     p1,
+		P2:     p2,
+		P3:     p3,
+		E1:     e1,
+		E2:     e2,
+		Normal: e2.Cross(e1).Normalize(),
+	}
+}
+
+func (t *Triangle) Name() string {
+	return "Triangle"
+}
+
+func (t *Triangle) LocalIntersect(r ray.Ray) []float64 {
+	res := []float64{}
+	for _, x := range t.LocalIntersectWithUV(r) {
+		res = append(res, x.T)
+	}
+	return res
+}
+
+func (t *Triangle) LocalIntersectWithUV(r ray.Ray) []Intersection {
+	dirCrossE2 := r.Direction.Cross(t.E2)
+	det := t.E1.Dot(dirCrossE2)
+	if math.Abs(det) < tuple.EPSILON {
+		return nil
+	}
+
+	f := 1.0 / det
+	p1ToOrigin := r.Origin.Subtract(t.P1)
+	u := f * p1ToOrigin.Dot(dirCrossE2)
+	if u < 0 || u > 1 {
+		return nil
+	}
+
+	originCrossE1 := p1ToOrigin.Cross(t.E1)
+	v := f * r.Direction.Dot(originCrossE1)
+	if v < 0 || u+v > 1 {
+		return nil
+	}
+
+	return []Intersection{
+		{T: f * t.E2.Dot(originCrossE1), U: u, V: v},
+	}
+}
+
+func (t *Triangle) LocalNormalAt(tuple.Tuple) tuple.Tuple {
+	return t.Normal
+}
+
+func (t *Triangle) Bounds() Bounds {
+	return EmptyBounds().AddPoint(t.P1).AddPoint(t.P2).AddPoint(t.P3)
+}
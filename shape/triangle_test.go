@@ -0,0 +1,68 @@
+package shape_test
+
+import (
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/shape"
+	"github.com/kieron-pivotal/rays/tuple"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Triangle", func() {
+
+	var (
+		p1, p2, p3 tuple.Tuple
+		o          *shape.Object
+		t          *shape.Triangle
+	)
+
+	BeforeEach(func() {
+		p1 = tuple.Point(0, 1, 0)
+		p2 = tuple.Point(-1, 0, 0)
+		p3 = tuple.Point(1, 0, 0)
+		o = shape.NewTriangle(p1, p2, p3)
+		t = o.LocalObject().(*shape.Triangle)
+	})
+
+	It("precomputes its edges and normal", func() {
+		Expect(t.P1).To(tuple.Equal(p1))
+		Expect(t.P2).To(tuple.Equal(p2))
+		Expect(t.P3).To(tuple.Equal(p3))
+		Expect(t.E1).To(tuple.Equal(tuple.Vector(-1, -1, 0)))
+		Expect(t.E2).To(tuple.Equal(tuple.Vector(1, -1, 0)))
+		Expect(t.Normal).To(tuple.Equal(tuple.Vector(0, 0, -1)))
+	})
+
+	It("has the same normal everywhere", func() {
+		Expect(t.LocalNormalAt(tuple.Point(0, 0.5, 0))).To(tuple.Equal(t.Normal))
+		Expect(t.LocalNormalAt(tuple.Point(-0.5, 0.75, 0))).To(tuple.Equal(t.Normal))
+		Expect(t.LocalNormalAt(tuple.Point(0.5, 0.25, 0))).To(tuple.Equal(t.Normal))
+	})
+
+	DescribeTable("ray misses triangle", func(origin, direction tuple.Tuple) {
+		r := ray.New(origin, direction)
+		Expect(t.LocalIntersect(r)).To(BeEmpty())
+	},
+
+		Entry("parallel", tuple.Point(0, -1, -2), tuple.Vector(0, 1, 0)),
+		Entry("p1-p3 edge", tuple.Point(1, 1, -2), tuple.Vector(0, 0, 1)),
+		Entry("p1-p2 edge", tuple.Point(-1, 1, -2), tuple.Vector(0, 0, 1)),
+		Entry("p2-p3 edge", tuple.Point(0, -1, -2), tuple.Vector(0, 0, 1)),
+	)
+
+	It("is struck by a ray", func() {
+		r := ray.New(tuple.Point(0, 0.5, -2), tuple.Vector(0, 0, 1))
+		xs := t.LocalIntersect(r)
+		Expect(xs).To(HaveLen(1))
+		Expect(xs[0]).To(BeNumerically("~", 2))
+	})
+
+	It("records u and v on its intersections", func() {
+		r := ray.New(tuple.Point(-0.2, 0.3, -2), tuple.Vector(0, 0, 1))
+		xs := o.Intersect(r)
+		Expect(xs.Count()).To(Equal(1))
+		Expect(xs.Get(0).U).To(BeNumerically("~", 0.45, tuple.EPSILON))
+		Expect(xs.Get(0).V).To(BeNumerically("~", 0.25, tuple.EPSILON))
+	})
+})
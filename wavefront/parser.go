@@ -0,0 +1,207 @@
+package wavefront
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/kieron-pivotal/rays/shape"
+	"github.com/kieron-pivotal/rays/tuple"
+)
+
+type LineError struct {
+	Line   int
+	Text   string
+	Reason string
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s: %q", e.Line, e.Reason, e.Text)
+}
+
+type Parser struct {
+	Vertices     []tuple.Tuple
+	Normals      []tuple.Tuple
+	DefaultGroup *shape.Object
+	Ignored      []*LineError
+
+	groups     map[string]*shape.Object
+	groupNames []string
+	current    *shape.Object
+	group      *shape.Object
+}
+
+type faceVertex struct {
+	vertex    int
+	normal    int
+	hasNormal bool
+}
+
+func Parse(r io.Reader) (*Parser, error) {
+	p := &Parser{
+		DefaultGroup: shape.NewGroup(),
+		groups:       map[string]*shape.Object{},
+	}
+	p.current = p.DefaultGroup
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		if err := p.parseLine(lineNum, scanner.Text()); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Parser) Group(name string) *shape.Object {
+	return p.groups[name]
+}
+
+// ToGroup gathers the default and named groups under one group. It is built
+// on the first call and the same group is returned after that, since a group
+// can only have one parent.
+func (p *Parser) ToGroup() *shape.Object {
+	if p.group != nil {
+		return p.group
+	}
+	g := shape.NewGroup()
+	if len(p.DefaultGroup.Children()) > 0 {
+		g.AddChild(p.DefaultGroup)
+	}
+	for _, name := range p.groupNames {
+		g.AddChild(p.groups[name])
+	}
+	p.group = g
+	return g
+}
+
+func (p *Parser) parseLine(lineNum int, line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return nil
+	}
+
+	lineErr := func(reason string) *LineError {
+		return &LineError{Line: lineNum, Text: line, Reason: reason}
+	}
+
+	switch fields[0] {
+	case "v":
+		x, y, z, err := parseTriple(fields[1:])
+		if err != nil {
+			return lineErr(err.Error())
+		}
+		p.Vertices = append(p.Vertices, tuple.Point(x, y, z))
+	case "vn":
+		x, y, z, err := parseTriple(fields[1:])
+		if err != nil {
+			return lineErr(err.Error())
+		}
+		p.Normals = append(p.Normals, tuple.Vector(x, y, z))
+	case "f":
+		if len(fields) < 4 {
+			return lineErr("a face needs at least three vertices")
+		}
+		fvs := make([]faceVertex, 0, len(fields)-1)
+		for _, f := range fields[1:] {
+			fv, err := p.parseFaceVertex(f)
+			if err != nil {
+				return lineErr(err.Error())
+			}
+			fvs = append(fvs, fv)
+		}
+		p.addFace(fvs)
+	case "g":
+		// a bare g switches back to the default group
+		if len(fields) < 2 {
+			p.current = p.DefaultGroup
+			return nil
+		}
+		name := strings.Join(fields[1:], " ")
+		if _, ok := p.groups[name]; !ok {
+			p.groups[name] = shape.NewGroup()
+			p.groupNames = append(p.groupNames, name)
+		}
+		p.current = p.groups[name]
+	default:
+		p.Ignored = append(p.Ignored, lineErr("unsupported statement"))
+	}
+	return nil
+}
+
+func parseTriple(fields []string) (x, y, z float64, err error) {
+	if len(fields) < 3 {
+		return 0, 0, 0, fmt.Errorf("expected 3 coordinates, got %d", len(fields))
+	}
+	vals := [3]float64{}
+	for i := 0; i < 3; i++ {
+		vals[i], err = strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid coordinate %q", fields[i])
+		}
+	}
+	return vals[0], vals[1], vals[2], nil
+}
+
+func (p *Parser) parseFaceVertex(s string) (faceVertex, error) {
+	parts := strings.Split(s, "/")
+	fv := faceVertex{}
+
+	v, err := resolveIndex(parts[0], len(p.Vertices))
+	if err != nil {
+		return fv, fmt.Errorf("vertex %s", err)
+	}
+	fv.vertex = v
+
+	if len(parts) == 3 && parts[2] != "" {
+		n, err := resolveIndex(parts[2], len(p.Normals))
+		if err != nil {
+			return fv, fmt.Errorf("normal %s", err)
+		}
+		fv.normal = n
+		fv.hasNormal = true
+	}
+	return fv, nil
+}
+
+func resolveIndex(s string, count int) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("index %q is not a number", s)
+	}
+	if i < 0 {
+		i = count + i + 1
+	}
+	if i < 1 || i > count {
+		return 0, fmt.Errorf("index %s out of range 1..%d", s, count)
+	}
+	return i - 1, nil
+}
+
+func (p *Parser) addFace(fvs []faceVertex) {
+	smooth := true
+	for _, fv := range fvs {
+		smooth = smooth && fv.hasNormal
+	}
+
+	for i := 1; i < len(fvs)-1; i++ {
+		a, b, c := fvs[0], fvs[i], fvs[i+1]
+		var t *shape.Object
+		if smooth {
+			t = shape.NewSmoothTriangle(
+				p.Vertices[a.vertex], p.Vertices[b.vertex], p.Vertices[c.vertex],
+				p.Normals[a.normal], p.Normals[b.normal], p.Normals[c.normal],
+			)
+		} else {
+			t = shape.NewTriangle(p.Vertices[a.vertex], p.Vertices[b.vertex], p.Vertices[c.vertex])
+		}
+		p.current.AddChild(t)
+	}
+}
@@ -0,0 +1,204 @@
+package wavefront_test
+
+import (
+	"strings"
+
+	"github.com/kieron-pivotal/rays/shape"
+	"github.com/kieron-pivotal/rays/tuple"
+	"github.com/kieron-pivotal/rays/wavefront"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parser", func() {
+
+	parse := func(s string) *wavefront.Parser {
+		p, err := wavefront.Parse(strings.NewReader(s))
+		Expect(err).NotTo(HaveOccurred())
+		return p
+	}
+
+	triangle := func(o *shape.Object) *shape.Triangle {
+		return o.LocalObject().(*shape.Triangle)
+	}
+
+	It("reports lines it doesn't understand", func() {
+		p := parse(`There was a young lady named Bright
+who traveled much faster than light.
+
+# a comment
+She set out one day
+in a relative way,
+and came back the previous night.`)
+		Expect(p.Ignored).To(HaveLen(5))
+		Expect(p.Ignored[0].Line).To(Equal(1))
+		Expect(p.Ignored[2].Line).To(Equal(5))
+		Expect(p.Ignored[2].Error()).To(ContainSubstring("line 5"))
+	})
+
+	It("parses vertices", func() {
+		p := parse(`v -1 1 0
+v -1.0000 0.5000 0.0000
+v 1 0 0
+v 1 1 0`)
+		Expect(p.Vertices).To(HaveLen(4))
+		Expect(p.Vertices[0]).To(tuple.Equal(tuple.Point(-1, 1, 0)))
+		Expect(p.Vertices[1]).To(tuple.Equal(tuple.Point(-1, 0.5, 0)))
+		Expect(p.Vertices[2]).To(tuple.Equal(tuple.Point(1, 0, 0)))
+		Expect(p.Vertices[3]).To(tuple.Equal(tuple.Point(1, 1, 0)))
+	})
+
+	It("parses triangle faces", func() {
+		p := parse(`v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+f 1 2 3
+f 1 3 4`)
+		children := p.DefaultGroup.Children()
+		Expect(children).To(HaveLen(2))
+		t1, t2 := triangle(children[0]), triangle(children[1])
+		Expect(t1.P1).To(tuple.Equal(p.Vertices[0]))
+		Expect(t1.P2).To(tuple.Equal(p.Vertices[1]))
+		Expect(t1.P3).To(tuple.Equal(p.Vertices[2]))
+		Expect(t2.P1).To(tuple.Equal(p.Vertices[0]))
+		Expect(t2.P2).To(tuple.Equal(p.Vertices[2]))
+		Expect(t2.P3).To(tuple.Equal(p.Vertices[3]))
+	})
+
+	It("triangulates polygons as a fan", func() {
+		p := parse(`v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+v 0 2 0
+
+f 1 2 3 4 5`)
+		children := p.DefaultGroup.Children()
+		Expect(children).To(HaveLen(3))
+		for i, c := range children {
+			t := triangle(c)
+			Expect(t.P1).To(tuple.Equal(p.Vertices[0]))
+			Expect(t.P2).To(tuple.Equal(p.Vertices[i+1]))
+			Expect(t.P3).To(tuple.Equal(p.Vertices[i+2]))
+		}
+	})
+
+	It("puts triangles into named groups", func() {
+		p := parse(`v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+g FirstGroup
+f 1 2 3
+g SecondGroup
+f 1 3 4`)
+		Expect(p.DefaultGroup.Children()).To(BeEmpty())
+		first := p.Group("FirstGroup")
+		second := p.Group("SecondGroup")
+		Expect(first.Children()).To(HaveLen(1))
+		Expect(second.Children()).To(HaveLen(1))
+		Expect(triangle(second.Children()[0]).P3).To(tuple.Equal(p.Vertices[3]))
+
+		g := p.ToGroup()
+		Expect(g.Children()).To(ConsistOf(first, second))
+	})
+
+	It("builds the combined group only once", func() {
+		p := parse(`v -1 1 0
+v -1 0 0
+v 1 0 0
+
+f 1 2 3
+g FirstGroup
+f 1 2 3`)
+		g := p.ToGroup()
+		Expect(p.ToGroup()).To(BeIdenticalTo(g))
+		Expect(g.Children()).To(HaveLen(2))
+		Expect(p.DefaultGroup.Parent()).To(BeIdenticalTo(g))
+		Expect(p.Group("FirstGroup").Parent()).To(BeIdenticalTo(g))
+	})
+
+	It("goes back to the default group on a bare g", func() {
+		p := parse(`v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+g FirstGroup
+f 1 2 3
+g
+f 1 3 4`)
+		Expect(p.Group("FirstGroup").Children()).To(HaveLen(1))
+		Expect(p.DefaultGroup.Children()).To(HaveLen(1))
+		Expect(triangle(p.DefaultGroup.Children()[0]).P3).To(tuple.Equal(p.Vertices[3]))
+		Expect(p.Ignored).To(BeEmpty())
+	})
+
+	It("parses vertex normals", func() {
+		p := parse(`vn 0 0 1
+vn 0.707 0 -0.707
+vn 1 2 3`)
+		Expect(p.Normals).To(HaveLen(3))
+		Expect(p.Normals[0]).To(tuple.Equal(tuple.Vector(0, 0, 1)))
+		Expect(p.Normals[1]).To(tuple.Equal(tuple.Vector(0.707, 0, -0.707)))
+		Expect(p.Normals[2]).To(tuple.Equal(tuple.Vector(1, 2, 3)))
+	})
+
+	It("makes smooth triangles from faces with normals", func() {
+		p := parse(`v 0 1 0
+v -1 0 0
+v 1 0 0
+
+vn -1 0 0
+vn 1 0 0
+vn 0 1 0
+
+f 1//3 2//1 3//2
+f 1/0/3 2/102/1 3/14/2`)
+		children := p.DefaultGroup.Children()
+		Expect(children).To(HaveLen(2))
+		for _, c := range children {
+			t := c.LocalObject().(*shape.SmoothTriangle)
+			Expect(t.P1).To(tuple.Equal(p.Vertices[0]))
+			Expect(t.P2).To(tuple.Equal(p.Vertices[1]))
+			Expect(t.P3).To(tuple.Equal(p.Vertices[2]))
+			Expect(t.N1).To(tuple.Equal(p.Normals[2]))
+			Expect(t.N2).To(tuple.Equal(p.Normals[0]))
+			Expect(t.N3).To(tuple.Equal(p.Normals[1]))
+		}
+	})
+
+	It("supports negative, relative indices", func() {
+		p := parse(`v -1 1 0
+v -1 0 0
+v 1 0 0
+f -3 -2 -1`)
+		t := triangle(p.DefaultGroup.Children()[0])
+		Expect(t.P1).To(tuple.Equal(p.Vertices[0]))
+		Expect(t.P3).To(tuple.Equal(p.Vertices[2]))
+	})
+
+	Context("malformed input", func() {
+		It("errors on a face with too few vertices", func() {
+			_, err := wavefront.Parse(strings.NewReader("v 0 0 0\nv 1 0 0\nf 1 2"))
+			Expect(err).To(MatchError(ContainSubstring("line 3")))
+		})
+
+		It("errors on a face referencing a missing vertex", func() {
+			_, err := wavefront.Parse(strings.NewReader("v 0 0 0\nv 1 0 0\nv 0 1 0\n\nf 1 2 7"))
+			Expect(err).To(HaveOccurred())
+			lineErr, ok := err.(*wavefront.LineError)
+			Expect(ok).To(BeTrue())
+			Expect(lineErr.Line).To(Equal(5))
+			Expect(lineErr.Reason).To(ContainSubstring("out of range"))
+		})
+
+		It("errors on a non-numeric vertex", func() {
+			_, err := wavefront.Parse(strings.NewReader("v 0 zero 0"))
+			Expect(err).To(MatchError(ContainSubstring("line 1")))
+		})
+	})
+})
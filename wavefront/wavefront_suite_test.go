@@ -0,0 +1,13 @@
+package wavefront_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestWavefront(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Wavefront Suite")
+}
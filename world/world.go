@@ -17,19 +17,25 @@ const (
 )
 
 type World struct {
-	Objects     []*shape.Object
-	LightSource *light.Point
+	Objects []*shape.Object
+	// Lights each add their own diffuse and specular contribution.
+	Lights []light.Light
+	// Ambient lights everything, scaled by each material's Ambient. It is
+	// counted once however many lights there are, so adding fill lights
+	// doesn't wash out the shadows.
+	Ambient color.Color
+	bvh     *shape.BVH
 }
 
 func New() *World {
-	w := World{}
+	w := World{Ambient: color.New(1, 1, 1)}
 	return &w
 }
 
 func Default() *World {
 	w := New()
 	lightSource := light.NewPoint(tuple.Point(-10, 10, -10), color.New(1, 1, 1))
-	w.LightSource = &lightSource
+	w.AddLight(lightSource)
 	obj1 := shape.NewSphere()
 	obj2 := shape.NewSphere()
 	mat1 := material.New()
@@ -45,16 +51,40 @@ func Default() *World {
 
 func (w *World) AddObject(obj *shape.Object) {
 	w.Objects = append(w.Objects, obj)
+	w.bvh = nil
+}
+
+func (w *World) AddLight(l light.Light) {
+	w.Lights = append(w.Lights, l)
+}
+
+func (w *World) Bounds() shape.Bounds {
+	b := shape.EmptyBounds()
+	for _, o := range w.Objects {
+		b = b.Merge(o.ParentSpaceBounds())
+	}
+	return b
+}
+
+// WithBVH returns a copy of w whose Intersections go through a bounding
+// volume hierarchy, for speeding up a single render of a large scene. w is
+// left alone. The copy doesn't see later changes to the objects, so don't
+// keep it once they might move.
+func (w *World) WithBVH() *World {
+	copied := *w
+	copied.Objects = append([]*shape.Object(nil), w.Objects...)
+	copied.Lights = append([]light.Light(nil), w.Lights...)
+	copied.bvh = shape.NewBVH(w.Objects)
+	return &copied
 }
 
 func (w *World) Intersections(r ray.Ray) *shape.Intersections {
+	if w.bvh != nil {
+		return w.bvh.Intersect(r)
+	}
 	ix := shape.NewIntersections()
 	for _, o := range w.Objects {
-		oix := o.Intersect(r)
-		for i := 0; i < oix.Count(); i++ {
-			intersection := oix.Get(i)
-			ix.Add(intersection.T, intersection.Object)
-		}
+		ix.Merge(o.Intersect(r))
 	}
 	return ix
 }
@@ -64,10 +94,12 @@ func (w *World) ShadeHit(comps shape.Computations, optRemaining ...int) color.Co
 	if len(optRemaining) == 1 {
 		remaining = optRemaining[0]
 	}
-	inShadow := w.InShadow(comps.OverPoint)
 	mat := comps.Object.Material()
-	surface := mat.Lighting(
-		*w.LightSource, comps.Object, comps.Point, comps.EyeV, comps.NormalV, inShadow)
+	surface := mat.AmbientLighting(w.Ambient, comps.Object, comps.Point)
+	for _, l := range w.Lights {
+		samples := w.shadowedSamples(comps.OverPoint, l)
+		surface = surface.Add(mat.DirectLighting(samples, comps.Object, comps.Point, comps.EyeV, comps.NormalV))
+	}
 	reflected := w.ReflectedColor(comps, remaining)
 	refracted := w.RefractedColor(comps, remaining)
 
@@ -78,12 +110,18 @@ func (w *World) ShadeHit(comps shape.Computations, optRemaining ...int) color.Co
 	return surface.Add(reflected).Add(refracted)
 }
 
+// ColorAt shades r as a camera ray, so objects hidden from the camera are
+// skipped.
 func (w *World) ColorAt(r ray.Ray, optRemaining ...int) color.Color {
 	remaining := REFLECT_MAX_RECURSION
 	if len(optRemaining) == 1 {
 		remaining = optRemaining[0]
 	}
-	ix := w.Intersections(r)
+	return w.colorAt(r, remaining, (*shape.Object).VisibleToCamera)
+}
+
+func (w *World) colorAt(r ray.Ray, remaining int, visible func(*shape.Object) bool) color.Color {
+	ix := w.Intersections(r).Filter(visible)
 	hit := ix.Hit()
 	if hit != nil {
 		comps := hit.PrepareComputations(r, ix)
@@ -92,13 +130,65 @@ func (w *World) ColorAt(r ray.Ray, optRemaining ...int) color.Color {
 	return color.Color{}
 }
 
-func (w *World) InShadow(p tuple.Tuple) bool {
-	pointToLight := w.LightSource.Position.Subtract(p)
-	distance := pointToLight.Magnitude()
-	ray := ray.New(p, pointToLight.Normalize())
-	ix := w.Intersections(ray)
-	hit := ix.Hit()
-	return hit != nil && hit.T < distance
+// InShadow returns how much of l reaches p, from 0 when p is completely
+// shadowed to 1 when every sample of the light is visible. Transparent
+// objects in the way let through their Transparency.
+func (w *World) InShadow(p tuple.Tuple, l light.Light) float64 {
+	samples := l.SamplesFrom(p)
+	if len(samples) == 0 {
+		return 0
+	}
+	lit := 0.0
+	for _, s := range samples {
+		lit += w.transmittance(ray.New(p, s.Direction), s.Distance)
+	}
+	return lit / float64(len(samples))
+}
+
+// shadowedSamples are l's samples from p, each dimmed by how much of it
+// reaches p, so every sample is both shadow tested and shaded.
+func (w *World) shadowedSamples(p tuple.Tuple, l light.Light) []light.Sample {
+	samples := l.SamplesFrom(p)
+	for i, s := range samples {
+		samples[i].Intensity = s.Intensity.Multiply(w.transmittance(ray.New(p, s.Direction), s.Distance))
+	}
+	return samples
+}
+
+// transmittance is the fraction of light that makes it along r for the
+// given distance. Each object only dims it once, however many of its
+// surfaces the ray crosses.
+func (w *World) transmittance(r ray.Ray, distance float64) float64 {
+	ix := w.Intersections(r)
+	through := 1.0
+	var seen []*shape.Object
+	for i := 0; i < ix.Count(); i++ {
+		hit := ix.Get(i)
+		if hit.T < 0 {
+			continue
+		}
+		if hit.T >= distance {
+			break
+		}
+		if !hit.Object.CastsShadow() || containsObject(seen, hit.Object) {
+			continue
+		}
+		through *= hit.Object.Material().Transparency
+		if through == 0 {
+			return 0
+		}
+		seen = append(seen, hit.Object)
+	}
+	return through
+}
+
+func containsObject(objects []*shape.Object, o *shape.Object) bool {
+	for _, obj := range objects {
+		if obj == o {
+			return true
+		}
+	}
+	return false
 }
 
 func (w *World) ReflectedColor(comps shape.Computations, remaining int) color.Color {
@@ -108,7 +198,7 @@ func (w *World) ReflectedColor(comps shape.Computations, remaining int) color.Co
 	}
 
 	reflectRay := ray.New(comps.OverPoint, comps.ReflectV)
-	color := w.ColorAt(reflectRay, remaining-1)
+	color := w.colorAt(reflectRay, remaining-1, (*shape.Object).VisibleInReflected)
 	return color.Multiply(m.Reflective)
 }
 
@@ -128,5 +218,5 @@ func (w *World) RefractedColor(comps shape.Computations, remaining int) color.Co
 	cosT := math.Sqrt(1 - sin2T)
 	direction := comps.NormalV.Multiply(nRatio*cosI - cosT).Subtract(comps.EyeV.Multiply(nRatio))
 	refractRay := ray.New(comps.UnderPoint, direction)
-	return w.ColorAt(refractRay, remaining-1).Multiply(comps.Object.Material().Transparency)
+	return w.colorAt(refractRay, remaining-1, (*shape.Object).VisibleInReflected).Multiply(comps.Object.Material().Transparency)
 }
@@ -0,0 +1,75 @@
+package world_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kieron-pivotal/rays/ray"
+	"github.com/kieron-pivotal/rays/shape"
+	"github.com/kieron-pivotal/rays/tuple"
+	"github.com/kieron-pivotal/rays/world"
+)
+
+// meshWorld builds a unit sphere tessellated into 2*n*n triangles.
+func meshWorld(n int) *world.World {
+	point := func(i, j int) tuple.Tuple {
+		theta := math.Pi * float64(i) / float64(n)
+		phi := 2 * math.Pi * float64(j) / float64(n)
+		return tuple.Point(
+			math.Sin(theta)*math.Cos(phi),
+			math.Cos(theta),
+			math.Sin(theta)*math.Sin(phi),
+		)
+	}
+
+	w := world.New()
+	g := shape.NewGroup()
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			p1, p2, p3, p4 := point(i, j), point(i+1, j), point(i+1, j+1), point(i, j+1)
+			if i > 0 {
+				g.AddChild(shape.NewTriangle(p1, p2, p4))
+			}
+			if i < n-1 {
+				g.AddChild(shape.NewTriangle(p2, p3, p4))
+			}
+		}
+	}
+	w.AddObject(g)
+	return w
+}
+
+func meshRays() []ray.Ray {
+	rays := []ray.Ray{}
+	for x := -10; x <= 10; x++ {
+		for y := -10; y <= 10; y++ {
+			rays = append(rays, ray.New(
+				tuple.Point(float64(x)/8, float64(y)/8, -5),
+				tuple.Vector(0, 0, 1),
+			))
+		}
+	}
+	return rays
+}
+
+func benchmarkMesh(b *testing.B, buildBVH bool) {
+	w := meshWorld(100)
+	if buildBVH {
+		w = w.WithBVH()
+	}
+	rays := meshRays()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, r := range rays {
+			w.Intersections(r)
+		}
+	}
+}
+
+func BenchmarkMeshBruteForce(b *testing.B) {
+	benchmarkMesh(b, false)
+}
+
+func BenchmarkMeshBVH(b *testing.B) {
+	benchmarkMesh(b, true)
+}
@@ -22,12 +22,14 @@ var _ = Describe("World", func() {
 	It("can create a world", func() {
 		w := world.New()
 		Expect(w.Objects).To(BeEmpty())
-		Expect(w.LightSource).To(BeNil())
+		Expect(w.Ambient).To(color.Equal(color.New(1, 1, 1)))
+		Expect(w.Lights).To(BeEmpty())
 	})
 
 	It("can create the default world", func() {
 		w := world.Default()
-		lightSource := w.LightSource
+		Expect(w.Lights).To(HaveLen(1))
+		lightSource := w.Lights[0].(light.Point)
 		Expect(lightSource.Position).To(tuple.Equal(tuple.Point(-10, 10, -10)))
 		Expect(lightSource.Intensity).To(color.Equal(color.New(1, 1, 1)))
 		Expect(w.Objects).To(HaveLen(2))
@@ -50,6 +52,66 @@ var _ = Describe("World", func() {
 		Expect(xs.Get(3).T).To(BeNumerically("~", 6))
 	})
 
+	It("can find ray intersections with objects inside a group", func() {
+		w := world.New()
+		g := shape.NewGroup()
+		g.SetTransform(matrix.Translation(0, 0, 5))
+		s := shape.NewSphere()
+		g.AddChild(s)
+		w.AddObject(g)
+		r := ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1))
+		xs := w.Intersections(r)
+		Expect(xs.Count()).To(Equal(2))
+		Expect(xs.Get(0).T).To(BeNumerically("~", 9))
+		Expect(xs.Get(0).Object).To(Equal(s))
+	})
+
+	It("knows the extents of its objects", func() {
+		w := world.Default()
+		s := shape.NewCube()
+		s.SetTransform(matrix.Translation(4, 0, 0))
+		w.AddObject(s)
+		b := w.Bounds()
+		Expect(b.Min).To(tuple.Equal(tuple.Point(-1, -1, -1)))
+		Expect(b.Max).To(tuple.Equal(tuple.Point(5, 1, 1)))
+	})
+
+	It("finds the same intersections once a BVH is built", func() {
+		w := world.Default()
+		floor := shape.NewPlane()
+		floor.SetTransform(matrix.Translation(0, -1, 0))
+		w.AddObject(floor)
+		r := ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, -0.1, 1).Normalize())
+		expected := w.Intersections(r)
+
+		xs := w.WithBVH().Intersections(r)
+		Expect(xs.Count()).To(Equal(expected.Count()))
+		for i := 0; i < xs.Count(); i++ {
+			Expect(xs.Get(i).T).To(BeNumerically("~", expected.Get(i).T))
+			Expect(xs.Get(i).Object).To(Equal(expected.Get(i).Object))
+		}
+	})
+
+	It("sees objects added to a copy with a BVH", func() {
+		w := world.Default().WithBVH()
+		s := shape.NewSphere()
+		s.SetTransform(matrix.Translation(0, 5, 0))
+		w.AddObject(s)
+		r := ray.New(tuple.Point(0, 5, -5), tuple.Vector(0, 0, 1))
+		Expect(w.Intersections(r).Count()).To(Equal(2))
+	})
+
+	It("leaves the world alone when making a copy with a BVH", func() {
+		w := world.Default()
+		withBVH := w.WithBVH()
+		withBVH.AddObject(shape.NewSphere())
+		Expect(w.Objects).To(HaveLen(2))
+
+		w.Objects[0].SetTransform(matrix.Translation(10, 0, 0))
+		r := ray.New(tuple.Point(10, 0, -5), tuple.Vector(0, 0, 1))
+		Expect(w.Intersections(r).Count()).To(Equal(2))
+	})
+
 	It("can shade an intersection", func() {
 		w := world.Default()
 		r := ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1))
@@ -65,7 +127,7 @@ var _ = Describe("World", func() {
 	It("can shade an intersection from the inside", func() {
 		w := world.Default()
 		l := light.NewPoint(tuple.Point(0, 0.25, 0), color.New(1, 1, 1))
-		w.LightSource = &l
+		w.Lights = []light.Light{l}
 		r := ray.New(tuple.Point(0, 0, 0), tuple.Vector(0, 0, 1))
 		s := w.Objects[1]
 		ix := shape.NewIntersections()
@@ -76,6 +138,136 @@ var _ = Describe("World", func() {
 		Expect(c).To(color.Equal(color.New(0.90498, 0.90498, 0.90498)))
 	})
 
+	Context("multiple lights", func() {
+		It("adds each light but only counts ambient once", func() {
+			w := world.Default()
+			w.AddLight(w.Lights[0])
+			r := ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1))
+			ix := shape.NewIntersections()
+			ix.Add(4, w.Objects[0])
+			comps := ix.Get(0).PrepareComputations(r, ix)
+			c := w.ShadeHit(comps)
+			Expect(c).To(color.Equal(color.New(0.68132, 0.85166, 0.5110)))
+		})
+
+		It("tests shadows separately for each light", func() {
+			w := world.New()
+			w.AddObject(shape.NewPlane())
+			blocker := shape.NewSphere()
+			blocker.SetTransform(matrix.Translation(0, 3, 0))
+			w.AddObject(blocker)
+			overhead := light.NewPoint(tuple.Point(0, 10, 0), color.New(1, 1, 1))
+			side := light.NewPoint(tuple.Point(10, 10, 0), color.New(1, 1, 1))
+
+			r2 := math.Sqrt(2)
+			r := ray.New(tuple.Point(0, 1, -1), tuple.Vector(0, -r2/2, r2/2))
+			shade := func(lights ...light.Light) color.Color {
+				w.Lights = lights
+				ix := w.Intersections(r)
+				return w.ShadeHit(ix.Hit().PrepareComputations(r, ix))
+			}
+
+			Expect(w.InShadow(tuple.Point(0, 0.1, 0), overhead)).To(BeZero())
+			Expect(w.InShadow(tuple.Point(0, 0.1, 0), side)).To(BeNumerically("~", 1))
+			Expect(shade(overhead)).To(color.Equal(color.New(0.1, 0.1, 0.1)))
+			Expect(shade(overhead, side)).To(color.Equal(shade(side)))
+		})
+
+		It("shadows each sample of an area light on its own", func() {
+			w := world.New()
+			w.AddObject(shape.NewPlane())
+			blocker := shape.NewSphere()
+			blocker.SetTransform(matrix.Translation(2.5, 5, 0))
+			w.AddObject(blocker)
+			area := light.NewArea(
+				tuple.Point(0, 10, 0),
+				tuple.Vector(20, 0, 0), 2,
+				tuple.Vector(0, 0, 0), 1,
+				color.New(1, 1, 1),
+			)
+			area.Jitter = false
+			unblocked := light.NewPoint(tuple.Point(15, 10, 0), color.New(0.5, 0.5, 0.5))
+
+			r2 := math.Sqrt(2)
+			r := ray.New(tuple.Point(0, 1, -1), tuple.Vector(0, -r2/2, r2/2))
+			shade := func(l light.Light) color.Color {
+				w.Lights = []light.Light{l}
+				ix := w.Intersections(r)
+				return w.ShadeHit(ix.Hit().PrepareComputations(r, ix))
+			}
+
+			Expect(w.InShadow(tuple.Point(0, 0.1, 0), area)).To(BeNumerically("~", 0.5))
+			Expect(shade(area)).To(color.Equal(shade(unblocked)))
+		})
+
+		It("only lights what a spot light is aimed at", func() {
+			w := world.Default()
+			r := ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1))
+			shade := func(target tuple.Tuple) color.Color {
+				spot := light.NewSpot(tuple.Point(-10, 10, -10), target.Subtract(tuple.Point(-10, 10, -10)), math.Pi/12, math.Pi/8, color.New(1, 1, 1))
+				w.Lights = []light.Light{spot}
+				ix := w.Intersections(r)
+				return w.ShadeHit(ix.Hit().PrepareComputations(r, ix))
+			}
+
+			Expect(shade(tuple.Point(0, 0, 0))).To(color.Equal(color.New(0.38066, 0.47583, 0.2855)))
+			Expect(shade(tuple.Point(0, 0, 20))).To(color.Equal(color.New(0.08, 0.1, 0.06)))
+		})
+
+		It("shadows spot lights", func() {
+			w := world.Default()
+			spot := light.NewSpot(tuple.Point(-10, 10, -10), tuple.Vector(1, -1, 1), math.Pi/12, math.Pi/8, color.New(1, 1, 1))
+			Expect(w.InShadow(tuple.Point(10, -10, 10), spot)).To(BeZero())
+			Expect(w.InShadow(tuple.Point(-2, 2, -2), spot)).To(BeNumerically("~", 1))
+		})
+
+		It("casts parallel shadows from a directional light however far away the caster is", func() {
+			w := world.New()
+			w.AddObject(shape.NewPlane())
+			caster := shape.NewSphere()
+			caster.SetTransform(matrix.Translation(0, 1000, 0))
+			w.AddObject(caster)
+			sun := light.NewDirectional(tuple.Vector(0, -1, 0), color.New(1, 1, 1))
+
+			Expect(w.InShadow(tuple.Point(0, 0.1, 0), sun)).To(BeZero())
+			Expect(w.InShadow(tuple.Point(0.9, 0.1, 0), sun)).To(BeZero())
+			Expect(w.InShadow(tuple.Point(1.1, 0.1, 0), sun)).To(BeNumerically("~", 1))
+		})
+
+		It("shades with a directional light", func() {
+			w := world.New()
+			w.AddObject(shape.NewPlane())
+			w.AddLight(light.NewDirectional(tuple.Vector(0, -1, 0), color.New(1, 1, 1)))
+			r := ray.New(tuple.Point(0, 1, 0), tuple.Vector(0, -1, 0))
+			Expect(w.ColorAt(r)).To(color.Equal(color.New(1.9, 1.9, 1.9)))
+		})
+
+		It("only has ambient light without any lights", func() {
+			w := world.Default()
+			w.Lights = nil
+			r := ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1))
+			Expect(w.ColorAt(r)).To(color.Equal(color.New(0.08, 0.1, 0.06)))
+
+			w.Ambient = color.New(0, 0, 0)
+			Expect(w.ColorAt(r)).To(color.Equal(color.New(0, 0, 0)))
+		})
+
+		It("takes ambient light from the world, whatever order the lights are in", func() {
+			w := world.Default()
+			spot := light.NewSpot(tuple.Point(0, 0, -10), tuple.Vector(0, 0, -1), math.Pi/12, math.Pi/8, color.New(1, 1, 1))
+			fill := light.NewPoint(tuple.Point(10, 10, -10), color.New(0.3, 0.3, 0.3))
+			r := ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1))
+
+			w.Lights = []light.Light{spot, fill}
+			first := w.ColorAt(r)
+			w.Lights = []light.Light{fill, spot}
+			Expect(w.ColorAt(r)).To(color.Equal(first))
+
+			w.Ambient = color.New(0.5, 0.5, 0.5)
+			Expect(w.ColorAt(r)).To(color.Equal(first.Subtract(color.New(0.04, 0.05, 0.03))))
+		})
+	})
+
 	Context("color for a ray", func() {
 		It("gives black for a missing ray", func() {
 			w := world.Default()
@@ -109,7 +301,7 @@ var _ = Describe("World", func() {
 		It("gets a shadow color correct", func() {
 			w := world.New()
 			lightSource := light.NewPoint(tuple.Point(0, 0, -10), color.New(1, 1, 1))
-			w.LightSource = &lightSource
+			w.AddLight(lightSource)
 			s1 := shape.NewSphere()
 			w.AddObject(s1)
 			s2 := shape.NewSphere()
@@ -127,7 +319,7 @@ var _ = Describe("World", func() {
 
 	DescribeTable("in shadow?", func(point tuple.Tuple, inShadow bool) {
 		w := world.Default()
-		Expect(w.InShadow(point)).To(Equal(inShadow))
+		Expect(w.InShadow(point, w.Lights[0]) == 0).To(Equal(inShadow))
 	},
 
 		Entry("nothing colinear with sphere and light", tuple.Point(0, 10, 0), false),
@@ -136,6 +328,149 @@ var _ = Describe("World", func() {
 		Entry("object behind the point", tuple.Point(-2, 2, -2), false),
 	)
 
+	DescribeTable("light reaching a point from an area light", func(point tuple.Tuple, expected float64) {
+		w := world.Default()
+		l := light.NewArea(
+			tuple.Point(-0.5, -0.5, -5),
+			tuple.Vector(1, 0, 0), 2,
+			tuple.Vector(0, 1, 0), 2,
+			color.New(1, 1, 1),
+		)
+		l.Jitter = false
+		Expect(w.InShadow(point, l)).To(BeNumerically("~", expected))
+	},
+		Entry("fully shadowed", tuple.Point(0, 0, 2), 0.0),
+		Entry("a quarter lit", tuple.Point(1, -1, 2), 0.25),
+		Entry("half lit", tuple.Point(1.5, 0, 2), 0.5),
+		Entry("three quarters lit", tuple.Point(1.25, 1.25, 3), 0.75),
+		Entry("fully lit", tuple.Point(0, 0, -2), 1.0),
+	)
+
+	It("doesn't shade with NaN when an area light has no steps", func() {
+		w := world.Default()
+		l := light.NewArea(
+			tuple.Point(-10, 10, -10),
+			tuple.Vector(1, 0, 0), 0,
+			tuple.Vector(0, 1, 0), 0,
+			color.New(1, 1, 1),
+		)
+		w.Lights = []light.Light{l}
+		Expect(w.InShadow(tuple.Point(0, 0, -2), l)).To(BeNumerically("~", 1))
+		c := w.ColorAt(ray.New(tuple.Point(0, 0, -5), tuple.Vector(0, 0, 1)))
+		Expect(math.IsNaN(c.Red())).To(BeFalse())
+		Expect(c.Red()).To(BeNumerically(">", 0.1))
+	})
+
+	Context("shadows through transparent objects", func() {
+		var (
+			w     *world.World
+			glass *shape.Object
+			l     light.Point
+			p     tuple.Tuple
+		)
+
+		BeforeEach(func() {
+			w = world.New()
+			glass = shape.NewGlassSphere()
+			glass.SetTransform(matrix.Translation(0, 5, 0))
+			w.AddObject(glass)
+			l = light.NewPoint(tuple.Point(0, 10, 0), color.New(1, 1, 1))
+			p = tuple.Point(0, 0, 0)
+		})
+
+		It("lets through the glass's transparency once per object", func() {
+			Expect(glass.Material().Transparency).To(BeNumerically("~", 1))
+			Expect(w.InShadow(p, l)).To(BeNumerically("~", 1))
+
+			m := glass.Material()
+			m.Transparency = 0.6
+			glass.SetMaterial(m)
+			Expect(w.InShadow(p, l)).To(BeNumerically("~", 0.6))
+		})
+
+		It("accumulates through several objects", func() {
+			m := glass.Material()
+			m.Transparency = 0.5
+			glass.SetMaterial(m)
+			other := shape.NewSphere()
+			other.SetTransform(matrix.Scaling(0.5, 0.5, 0.5).Translate(0, 2, 0))
+			om := other.Material()
+			om.Transparency = 0.4
+			other.SetMaterial(om)
+			w.AddObject(other)
+
+			Expect(w.InShadow(p, l)).To(BeNumerically("~", 0.2))
+		})
+
+		It("ignores objects beyond the light", func() {
+			m := glass.Material()
+			m.Transparency = 0.5
+			glass.SetMaterial(m)
+			l.Position = tuple.Point(0, 3, 0)
+			Expect(w.InShadow(p, l)).To(BeNumerically("~", 1))
+		})
+
+		It("can opt an opaque object out of casting shadows", func() {
+			m := glass.Material()
+			m.Transparency = 0
+			glass.SetMaterial(m)
+			Expect(w.InShadow(p, l)).To(BeZero())
+
+			glass.SetCastsShadow(false)
+			Expect(w.InShadow(p, l)).To(BeNumerically("~", 1))
+		})
+	})
+
+	Context("per-object flags", func() {
+		var (
+			w       *world.World
+			blocker *shape.Object
+			floor   *shape.Object
+		)
+
+		BeforeEach(func() {
+			w = world.New()
+			w.AddLight(light.NewPoint(tuple.Point(0, 10, 0), color.New(1, 1, 1)))
+			floor = shape.NewPlane()
+			w.AddObject(floor)
+			blocker = shape.NewSphere()
+			blocker.SetTransform(matrix.Translation(0, 5, 0))
+			w.AddObject(blocker)
+		})
+
+		It("lets camera rays pass through invisible light blockers", func() {
+			down := ray.New(tuple.Point(0, 20, 0), tuple.Vector(0, -1, 0))
+			Expect(w.ColorAt(down)).To(color.Equal(color.New(1.9, 1.9, 1.9)))
+
+			blocker.SetVisibleToCamera(false)
+			Expect(w.ColorAt(down)).To(color.Equal(color.New(0.1, 0.1, 0.1)))
+			Expect(w.InShadow(tuple.Point(0, 0.1, 0), w.Lights[0])).To(BeZero())
+		})
+
+		It("can stop an object casting shadows", func() {
+			r := ray.New(tuple.Point(0, 1, -1), tuple.Vector(0, -1, 1).Normalize())
+			Expect(w.ColorAt(r)).To(color.Equal(color.New(0.1, 0.1, 0.1)))
+
+			blocker.SetCastsShadow(false)
+			Expect(w.InShadow(tuple.Point(0, 0.1, 0), w.Lights[0])).To(BeNumerically("~", 1))
+			Expect(w.ColorAt(r)).To(color.Equal(color.New(1, 1, 1)))
+		})
+
+		It("can hide an object from reflections and refractions", func() {
+			m := floor.Material()
+			m.Reflective = 1
+			floor.SetMaterial(m)
+			r := ray.New(tuple.Point(0, 5, -10), tuple.Vector(0, -1, 1).Normalize())
+			ix := w.Intersections(r)
+			comps := ix.Hit().PrepareComputations(r, ix)
+			Expect(w.ReflectedColor(comps, 5)).NotTo(color.Equal(color.New(0, 0, 0)))
+
+			blocker.SetVisibleInReflected(false)
+			Expect(w.ReflectedColor(comps, 5)).To(color.Equal(color.New(0, 0, 0)))
+			Expect(w.ColorAt(ray.New(tuple.Point(0, 5, -10), tuple.Vector(0, 0, 1)))).NotTo(color.Equal(color.New(0, 0, 0)))
+		})
+	})
+
 	Context("reflection", func() {
 		It("returns black when a ray reflects from a non-reflective surface", func() {
 			w := world.Default()
@@ -193,7 +528,7 @@ var _ = Describe("World", func() {
 		It("avoids infinite recursion", func() {
 			w := world.New()
 			l := light.NewPoint(tuple.Point(0, 0, 0), color.New(1, 1, 1))
-			w.LightSource = &l
+			w.AddLight(l)
 
 			lower := shape.NewPlane()
 			lower.SetTransform(matrix.Translation(0, -1, 0))
@@ -345,7 +680,8 @@ var _ = Describe("World", func() {
 
 			comps := ix.Get(0).PrepareComputations(r, ix)
 			c := w.ShadeHit(comps, 5)
-			Expect(c).To(color.Equal(color.New(0.93642, 0.68642, 0.68642)))
+			// the ball is lit through the half transparent floor
+			Expect(c).To(color.Equal(color.New(1.12547, 0.68643, 0.68643)))
 		})
 	})
 
@@ -382,7 +718,8 @@ var _ = Describe("World", func() {
 				comps := i.PrepareComputations(r, xs)
 
 				c := w.ShadeHit(comps, 5)
-				Expect(c).To(color.Equal(color.New(0.93391, 0.69643, 0.69243)))
+				// the ball is lit through the half transparent floor
+				Expect(c).To(color.Equal(color.New(1.11500, 0.69643, 0.69243)))
 
 			})
 		})